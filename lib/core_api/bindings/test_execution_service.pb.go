@@ -261,6 +261,105 @@ func (x *RegisterServiceResponse) GetIpAddr() string {
 	return ""
 }
 
+// ==============================================================================================
+//                                     Create Shared Volume
+// ==============================================================================================
+type CreateSharedVolumeArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// ID that will be used to identify the shared volume when mounting it into services
+	VolumeId string `protobuf:"bytes,1,opt,name=volume_id,json=volumeId,proto3" json:"volume_id,omitempty"`
+}
+
+func (x *CreateSharedVolumeArgs) Reset() {
+	*x = CreateSharedVolumeArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSharedVolumeArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSharedVolumeArgs) ProtoMessage() {}
+
+func (x *CreateSharedVolumeArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSharedVolumeArgs.ProtoReflect.Descriptor instead.
+func (*CreateSharedVolumeArgs) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateSharedVolumeArgs) GetVolumeId() string {
+	if x != nil {
+		return x.VolumeId
+	}
+	return ""
+}
+
+type CreateSharedVolumeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Dirpath (RELATIVE to the suite execution volume root!) of the directory backing the shared volume
+	RelativeDirpath string `protobuf:"bytes,1,opt,name=relative_dirpath,json=relativeDirpath,proto3" json:"relative_dirpath,omitempty"`
+}
+
+func (x *CreateSharedVolumeResponse) Reset() {
+	*x = CreateSharedVolumeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSharedVolumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSharedVolumeResponse) ProtoMessage() {}
+
+func (x *CreateSharedVolumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSharedVolumeResponse.ProtoReflect.Descriptor instead.
+func (*CreateSharedVolumeResponse) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateSharedVolumeResponse) GetRelativeDirpath() string {
+	if x != nil {
+		return x.RelativeDirpath
+	}
+	return ""
+}
+
 // ==============================================================================================
 //                                        Start Service
 // ==============================================================================================
@@ -269,39 +368,614 @@ type StartServiceArgs struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// ID of the previously-registered service that should be started
-	ServiceId   string `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
-	DockerImage string `protobuf:"bytes,2,opt,name=docker_image,json=dockerImage,proto3" json:"docker_image,omitempty"`
-	// "Set" of ports that the running service will listen on
-	// This is a string because it's Docker port specification syntax, e.g. "80" (default TCP) or "80/udp"
-	UsedPorts map[string]bool `protobuf:"bytes,3,rep,name=used_ports,json=usedPorts,proto3" json:"used_ports,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
-	// String array indicating the command that should be run inside the sevice's container on startup
-	StartCmdArgs []string `protobuf:"bytes,4,rep,name=start_cmd_args,json=startCmdArgs,proto3" json:"start_cmd_args,omitempty"`
-	// Docker environment variables that should be set in the service's container
-	DockerEnvVars map[string]string `protobuf:"bytes,5,rep,name=docker_env_vars,json=dockerEnvVars,proto3" json:"docker_env_vars,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// The full path where the API container should execute the suite execution volume on the service container
-	SuiteExecutionVolMntDirpath string `protobuf:"bytes,6,opt,name=suite_execution_vol_mnt_dirpath,json=suiteExecutionVolMntDirpath,proto3" json:"suite_execution_vol_mnt_dirpath,omitempty"`
-	// Mapping of artifact_url -> filepath_on_container_to_mount_artifact_contents
-	FilesArtifactMountDirpaths map[string]string `protobuf:"bytes,7,rep,name=files_artifact_mount_dirpaths,json=filesArtifactMountDirpaths,proto3" json:"files_artifact_mount_dirpaths,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// ID of the previously-registered service that should be started
+	ServiceId   string `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	DockerImage string `protobuf:"bytes,2,opt,name=docker_image,json=dockerImage,proto3" json:"docker_image,omitempty"`
+	// "Set" of ports that the running service will listen on
+	// This is a string because it's Docker port specification syntax, e.g. "80" (default TCP) or "80/udp"
+	UsedPorts map[string]bool `protobuf:"bytes,3,rep,name=used_ports,json=usedPorts,proto3" json:"used_ports,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// String array indicating the command that should be run inside the sevice's container on startup
+	StartCmdArgs []string `protobuf:"bytes,4,rep,name=start_cmd_args,json=startCmdArgs,proto3" json:"start_cmd_args,omitempty"`
+	// Docker environment variables that should be set in the service's container
+	DockerEnvVars map[string]string `protobuf:"bytes,5,rep,name=docker_env_vars,json=dockerEnvVars,proto3" json:"docker_env_vars,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// The full path where the API container should execute the suite execution volume on the service container
+	SuiteExecutionVolMntDirpath string `protobuf:"bytes,6,opt,name=suite_execution_vol_mnt_dirpath,json=suiteExecutionVolMntDirpath,proto3" json:"suite_execution_vol_mnt_dirpath,omitempty"`
+	// Mapping of artifact_url -> filepath_on_container_to_mount_artifact_contents
+	FilesArtifactMountDirpaths map[string]string `protobuf:"bytes,7,rep,name=files_artifact_mount_dirpaths,json=filesArtifactMountDirpaths,proto3" json:"files_artifact_mount_dirpaths,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Constraints on the resources the service's container can use; if unset, the container is unconstrained
+	ResourceLimits *ResourceLimits `protobuf:"bytes,8,opt,name=resource_limits,json=resourceLimits,proto3" json:"resource_limits,omitempty"`
+	// Mapping of shared_volume_id -> dirpath_on_container_to_mount_shared_volume, where the volumes have been created
+	//  previously with CreateSharedVolume
+	SharedVolumeMountDirpaths map[string]string `protobuf:"bytes,9,rep,name=shared_volume_mount_dirpaths,json=sharedVolumeMountDirpaths,proto3" json:"shared_volume_mount_dirpaths,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Mapping of archive_relative_filepath -> filepath_on_container_to_mount_artifact_contents, for files artifacts that
+	//  the testsuite packed itself as GZ-compressed TAR archives on the suite execution volume (paths RELATIVE to the suite
+	//  execution volume root!); their contents are mounted exactly like the artifacts downloaded from URLs
+	LocalFilesArtifactMountDirpaths map[string]string `protobuf:"bytes,10,rep,name=local_files_artifact_mount_dirpaths,json=localFilesArtifactMountDirpaths,proto3" json:"local_files_artifact_mount_dirpaths,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *StartServiceArgs) Reset() {
+	*x = StartServiceArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StartServiceArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartServiceArgs) ProtoMessage() {}
+
+func (x *StartServiceArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartServiceArgs.ProtoReflect.Descriptor instead.
+func (*StartServiceArgs) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *StartServiceArgs) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *StartServiceArgs) GetDockerImage() string {
+	if x != nil {
+		return x.DockerImage
+	}
+	return ""
+}
+
+func (x *StartServiceArgs) GetUsedPorts() map[string]bool {
+	if x != nil {
+		return x.UsedPorts
+	}
+	return nil
+}
+
+func (x *StartServiceArgs) GetStartCmdArgs() []string {
+	if x != nil {
+		return x.StartCmdArgs
+	}
+	return nil
+}
+
+func (x *StartServiceArgs) GetDockerEnvVars() map[string]string {
+	if x != nil {
+		return x.DockerEnvVars
+	}
+	return nil
+}
+
+func (x *StartServiceArgs) GetSuiteExecutionVolMntDirpath() string {
+	if x != nil {
+		return x.SuiteExecutionVolMntDirpath
+	}
+	return ""
+}
+
+func (x *StartServiceArgs) GetFilesArtifactMountDirpaths() map[string]string {
+	if x != nil {
+		return x.FilesArtifactMountDirpaths
+	}
+	return nil
+}
+
+func (x *StartServiceArgs) GetResourceLimits() *ResourceLimits {
+	if x != nil {
+		return x.ResourceLimits
+	}
+	return nil
+}
+
+func (x *StartServiceArgs) GetSharedVolumeMountDirpaths() map[string]string {
+	if x != nil {
+		return x.SharedVolumeMountDirpaths
+	}
+	return nil
+}
+
+func (x *StartServiceArgs) GetLocalFilesArtifactMountDirpaths() map[string]string {
+	if x != nil {
+		return x.LocalFilesArtifactMountDirpaths
+	}
+	return nil
+}
+
+type ResourceLimits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// CPU quota, in thousandths of a CPU (e.g. 1500 = 1.5 CPUs); 0 means unlimited
+	CpuMillicores uint64 `protobuf:"varint,1,opt,name=cpu_millicores,json=cpuMillicores,proto3" json:"cpu_millicores,omitempty"`
+	// Maximum memory the container can use, in bytes; 0 means unlimited
+	MemoryLimitBytes uint64 `protobuf:"varint,2,opt,name=memory_limit_bytes,json=memoryLimitBytes,proto3" json:"memory_limit_bytes,omitempty"`
+	// Maximum number of processes the container can run; 0 means unlimited
+	PidsLimit uint64 `protobuf:"varint,3,opt,name=pids_limit,json=pidsLimit,proto3" json:"pids_limit,omitempty"`
+}
+
+func (x *ResourceLimits) Reset() {
+	*x = ResourceLimits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResourceLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceLimits) ProtoMessage() {}
+
+func (x *ResourceLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceLimits.ProtoReflect.Descriptor instead.
+func (*ResourceLimits) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ResourceLimits) GetCpuMillicores() uint64 {
+	if x != nil {
+		return x.CpuMillicores
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetMemoryLimitBytes() uint64 {
+	if x != nil {
+		return x.MemoryLimitBytes
+	}
+	return 0
+}
+
+func (x *ResourceLimits) GetPidsLimit() uint64 {
+	if x != nil {
+		return x.PidsLimit
+	}
+	return 0
+}
+
+// ==============================================================================================
+//                                Update Service Resource Limits
+// ==============================================================================================
+type UpdateServiceResourceLimitsArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceId string `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// The new resource limits, which replace the service container's existing limits entirely
+	ResourceLimits *ResourceLimits `protobuf:"bytes,2,opt,name=resource_limits,json=resourceLimits,proto3" json:"resource_limits,omitempty"`
+}
+
+func (x *UpdateServiceResourceLimitsArgs) Reset() {
+	*x = UpdateServiceResourceLimitsArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateServiceResourceLimitsArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateServiceResourceLimitsArgs) ProtoMessage() {}
+
+func (x *UpdateServiceResourceLimitsArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateServiceResourceLimitsArgs.ProtoReflect.Descriptor instead.
+func (*UpdateServiceResourceLimitsArgs) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateServiceResourceLimitsArgs) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *UpdateServiceResourceLimitsArgs) GetResourceLimits() *ResourceLimits {
+	if x != nil {
+		return x.ResourceLimits
+	}
+	return nil
+}
+
+// ==============================================================================================
+//                                        Remove Service
+// ==============================================================================================
+type RemoveServiceArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceId string `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// How long to wait for the service to gracefully stop before hard killing it
+	ContainerStopTimeoutSeconds uint64 `protobuf:"varint,2,opt,name=container_stop_timeout_seconds,json=containerStopTimeoutSeconds,proto3" json:"container_stop_timeout_seconds,omitempty"`
+}
+
+func (x *RemoveServiceArgs) Reset() {
+	*x = RemoveServiceArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RemoveServiceArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveServiceArgs) ProtoMessage() {}
+
+func (x *RemoveServiceArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveServiceArgs.ProtoReflect.Descriptor instead.
+func (*RemoveServiceArgs) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RemoveServiceArgs) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *RemoveServiceArgs) GetContainerStopTimeoutSeconds() uint64 {
+	if x != nil {
+		return x.ContainerStopTimeoutSeconds
+	}
+	return 0
+}
+
+// ==============================================================================================
+//                                     Wait For Service Exit
+// ==============================================================================================
+type WaitForServiceExitArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceId string `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// How long to wait for the service's container to exit before returning an error
+	TimeoutSeconds uint64 `protobuf:"varint,2,opt,name=timeout_seconds,json=timeoutSeconds,proto3" json:"timeout_seconds,omitempty"`
+}
+
+func (x *WaitForServiceExitArgs) Reset() {
+	*x = WaitForServiceExitArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WaitForServiceExitArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitForServiceExitArgs) ProtoMessage() {}
+
+func (x *WaitForServiceExitArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitForServiceExitArgs.ProtoReflect.Descriptor instead.
+func (*WaitForServiceExitArgs) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WaitForServiceExitArgs) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *WaitForServiceExitArgs) GetTimeoutSeconds() uint64 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+type WaitForServiceExitResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExitCode int32 `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+}
+
+func (x *WaitForServiceExitResponse) Reset() {
+	*x = WaitForServiceExitResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WaitForServiceExitResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitForServiceExitResponse) ProtoMessage() {}
+
+func (x *WaitForServiceExitResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitForServiceExitResponse.ProtoReflect.Descriptor instead.
+func (*WaitForServiceExitResponse) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *WaitForServiceExitResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+// ==============================================================================================
+//                                         Exec Command
+// ==============================================================================================
+type ExecCommandArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceId string `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	// The command to run inside the service's container, as an array of arguments
+	CommandArgs []string `protobuf:"bytes,2,rep,name=command_args,json=commandArgs,proto3" json:"command_args,omitempty"`
+}
+
+func (x *ExecCommandArgs) Reset() {
+	*x = ExecCommandArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecCommandArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecCommandArgs) ProtoMessage() {}
+
+func (x *ExecCommandArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecCommandArgs.ProtoReflect.Descriptor instead.
+func (*ExecCommandArgs) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ExecCommandArgs) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ExecCommandArgs) GetCommandArgs() []string {
+	if x != nil {
+		return x.CommandArgs
+	}
+	return nil
+}
+
+type ExecCommandResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ExitCode int32 `protobuf:"varint,1,opt,name=exit_code,json=exitCode,proto3" json:"exit_code,omitempty"`
+	// The combined STDOUT & STDERR output of the command
+	LogOutput []byte `protobuf:"bytes,2,opt,name=log_output,json=logOutput,proto3" json:"log_output,omitempty"`
+}
+
+func (x *ExecCommandResponse) Reset() {
+	*x = ExecCommandResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExecCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExecCommandResponse) ProtoMessage() {}
+
+func (x *ExecCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExecCommandResponse.ProtoReflect.Descriptor instead.
+func (*ExecCommandResponse) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ExecCommandResponse) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *ExecCommandResponse) GetLogOutput() []byte {
+	if x != nil {
+		return x.LogOutput
+	}
+	return nil
+}
+
+// ==============================================================================================
+//                                       Get Service Logs
+// ==============================================================================================
+type GetServiceLogsArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceId string `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+}
+
+func (x *GetServiceLogsArgs) Reset() {
+	*x = GetServiceLogsArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetServiceLogsArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetServiceLogsArgs) ProtoMessage() {}
+
+func (x *GetServiceLogsArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetServiceLogsArgs.ProtoReflect.Descriptor instead.
+func (*GetServiceLogsArgs) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetServiceLogsArgs) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+type GetServiceLogsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The combined STDOUT & STDERR output of the service's container
+	Logs []byte `protobuf:"bytes,1,opt,name=logs,proto3" json:"logs,omitempty"`
 }
 
-func (x *StartServiceArgs) Reset() {
-	*x = StartServiceArgs{}
+func (x *GetServiceLogsResponse) Reset() {
+	*x = GetServiceLogsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_execution_service_proto_msgTypes[4]
+		mi := &file_test_execution_service_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StartServiceArgs) String() string {
+func (x *GetServiceLogsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartServiceArgs) ProtoMessage() {}
+func (*GetServiceLogsResponse) ProtoMessage() {}
 
-func (x *StartServiceArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_test_execution_service_proto_msgTypes[4]
+func (x *GetServiceLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -312,90 +986,146 @@ func (x *StartServiceArgs) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartServiceArgs.ProtoReflect.Descriptor instead.
-func (*StartServiceArgs) Descriptor() ([]byte, []int) {
-	return file_test_execution_service_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GetServiceLogsResponse.ProtoReflect.Descriptor instead.
+func (*GetServiceLogsResponse) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *StartServiceArgs) GetServiceId() string {
+func (x *GetServiceLogsResponse) GetLogs() []byte {
 	if x != nil {
-		return x.ServiceId
+		return x.Logs
 	}
-	return ""
+	return nil
 }
 
-func (x *StartServiceArgs) GetDockerImage() string {
-	if x != nil {
-		return x.DockerImage
+// ==============================================================================================
+//                                        Inspect Service
+// ==============================================================================================
+type InspectServiceArgs struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ServiceId string `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+}
+
+func (x *InspectServiceArgs) Reset() {
+	*x = InspectServiceArgs{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return ""
 }
 
-func (x *StartServiceArgs) GetUsedPorts() map[string]bool {
-	if x != nil {
-		return x.UsedPorts
+func (x *InspectServiceArgs) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectServiceArgs) ProtoMessage() {}
+
+func (x *InspectServiceArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *StartServiceArgs) GetStartCmdArgs() []string {
+// Deprecated: Use InspectServiceArgs.ProtoReflect.Descriptor instead.
+func (*InspectServiceArgs) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *InspectServiceArgs) GetServiceId() string {
 	if x != nil {
-		return x.StartCmdArgs
+		return x.ServiceId
 	}
-	return nil
+	return ""
 }
 
-func (x *StartServiceArgs) GetDockerEnvVars() map[string]string {
-	if x != nil {
-		return x.DockerEnvVars
+type InspectServiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The JSON-serialized container information, in the same format as 'docker inspect'
+	InspectJson []byte `protobuf:"bytes,1,opt,name=inspect_json,json=inspectJson,proto3" json:"inspect_json,omitempty"`
+}
+
+func (x *InspectServiceResponse) Reset() {
+	*x = InspectServiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *StartServiceArgs) GetSuiteExecutionVolMntDirpath() string {
-	if x != nil {
-		return x.SuiteExecutionVolMntDirpath
+func (x *InspectServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InspectServiceResponse) ProtoMessage() {}
+
+func (x *InspectServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *StartServiceArgs) GetFilesArtifactMountDirpaths() map[string]string {
+// Deprecated: Use InspectServiceResponse.ProtoReflect.Descriptor instead.
+func (*InspectServiceResponse) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *InspectServiceResponse) GetInspectJson() []byte {
 	if x != nil {
-		return x.FilesArtifactMountDirpaths
+		return x.InspectJson
 	}
 	return nil
 }
 
 // ==============================================================================================
-//                                        Remove Service
+//                                       Copy From Service
 // ==============================================================================================
-type RemoveServiceArgs struct {
+type CopyFromServiceArgs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	ServiceId string `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
-	// How long to wait for the service to gracefully stop before hard killing it
-	ContainerStopTimeoutSeconds uint64 `protobuf:"varint,2,opt,name=container_stop_timeout_seconds,json=containerStopTimeoutSeconds,proto3" json:"container_stop_timeout_seconds,omitempty"`
+	// Absolute path of the file or directory inside the service's container that should be copied
+	ContainerPath string `protobuf:"bytes,2,opt,name=container_path,json=containerPath,proto3" json:"container_path,omitempty"`
 }
 
-func (x *RemoveServiceArgs) Reset() {
-	*x = RemoveServiceArgs{}
+func (x *CopyFromServiceArgs) Reset() {
+	*x = CopyFromServiceArgs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_execution_service_proto_msgTypes[5]
+		mi := &file_test_execution_service_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *RemoveServiceArgs) String() string {
+func (x *CopyFromServiceArgs) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveServiceArgs) ProtoMessage() {}
+func (*CopyFromServiceArgs) ProtoMessage() {}
 
-func (x *RemoveServiceArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_test_execution_service_proto_msgTypes[5]
+func (x *CopyFromServiceArgs) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -406,23 +1136,71 @@ func (x *RemoveServiceArgs) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveServiceArgs.ProtoReflect.Descriptor instead.
-func (*RemoveServiceArgs) Descriptor() ([]byte, []int) {
-	return file_test_execution_service_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use CopyFromServiceArgs.ProtoReflect.Descriptor instead.
+func (*CopyFromServiceArgs) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *RemoveServiceArgs) GetServiceId() string {
+func (x *CopyFromServiceArgs) GetServiceId() string {
 	if x != nil {
 		return x.ServiceId
 	}
 	return ""
 }
 
-func (x *RemoveServiceArgs) GetContainerStopTimeoutSeconds() uint64 {
+func (x *CopyFromServiceArgs) GetContainerPath() string {
 	if x != nil {
-		return x.ContainerStopTimeoutSeconds
+		return x.ContainerPath
 	}
-	return 0
+	return ""
+}
+
+type CopyFromServiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Filepath (RELATIVE to the suite execution volume root!) of the TAR archive containing the copied path
+	ArchiveRelativeFilepath string `protobuf:"bytes,1,opt,name=archive_relative_filepath,json=archiveRelativeFilepath,proto3" json:"archive_relative_filepath,omitempty"`
+}
+
+func (x *CopyFromServiceResponse) Reset() {
+	*x = CopyFromServiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_test_execution_service_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CopyFromServiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CopyFromServiceResponse) ProtoMessage() {}
+
+func (x *CopyFromServiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_test_execution_service_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CopyFromServiceResponse.ProtoReflect.Descriptor instead.
+func (*CopyFromServiceResponse) Descriptor() ([]byte, []int) {
+	return file_test_execution_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CopyFromServiceResponse) GetArchiveRelativeFilepath() string {
+	if x != nil {
+		return x.ArchiveRelativeFilepath
+	}
+	return ""
 }
 
 // ==============================================================================================
@@ -445,7 +1223,7 @@ type RepartitionArgs struct {
 func (x *RepartitionArgs) Reset() {
 	*x = RepartitionArgs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_execution_service_proto_msgTypes[6]
+		mi := &file_test_execution_service_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -458,7 +1236,7 @@ func (x *RepartitionArgs) String() string {
 func (*RepartitionArgs) ProtoMessage() {}
 
 func (x *RepartitionArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_test_execution_service_proto_msgTypes[6]
+	mi := &file_test_execution_service_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -471,7 +1249,7 @@ func (x *RepartitionArgs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RepartitionArgs.ProtoReflect.Descriptor instead.
 func (*RepartitionArgs) Descriptor() ([]byte, []int) {
-	return file_test_execution_service_proto_rawDescGZIP(), []int{6}
+	return file_test_execution_service_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RepartitionArgs) GetPartitionServices() map[string]*PartitionServices {
@@ -507,7 +1285,7 @@ type PartitionServices struct {
 func (x *PartitionServices) Reset() {
 	*x = PartitionServices{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_execution_service_proto_msgTypes[7]
+		mi := &file_test_execution_service_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -520,7 +1298,7 @@ func (x *PartitionServices) String() string {
 func (*PartitionServices) ProtoMessage() {}
 
 func (x *PartitionServices) ProtoReflect() protoreflect.Message {
-	mi := &file_test_execution_service_proto_msgTypes[7]
+	mi := &file_test_execution_service_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,7 +1311,7 @@ func (x *PartitionServices) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PartitionServices.ProtoReflect.Descriptor instead.
 func (*PartitionServices) Descriptor() ([]byte, []int) {
-	return file_test_execution_service_proto_rawDescGZIP(), []int{7}
+	return file_test_execution_service_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *PartitionServices) GetServiceIdSet() map[string]bool {
@@ -554,7 +1332,7 @@ type PartitionConnections struct {
 func (x *PartitionConnections) Reset() {
 	*x = PartitionConnections{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_execution_service_proto_msgTypes[8]
+		mi := &file_test_execution_service_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -567,7 +1345,7 @@ func (x *PartitionConnections) String() string {
 func (*PartitionConnections) ProtoMessage() {}
 
 func (x *PartitionConnections) ProtoReflect() protoreflect.Message {
-	mi := &file_test_execution_service_proto_msgTypes[8]
+	mi := &file_test_execution_service_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -580,7 +1358,7 @@ func (x *PartitionConnections) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PartitionConnections.ProtoReflect.Descriptor instead.
 func (*PartitionConnections) Descriptor() ([]byte, []int) {
-	return file_test_execution_service_proto_rawDescGZIP(), []int{8}
+	return file_test_execution_service_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *PartitionConnections) GetConnectionInfo() map[string]*PartitionConnectionInfo {
@@ -602,7 +1380,7 @@ type PartitionConnectionInfo struct {
 func (x *PartitionConnectionInfo) Reset() {
 	*x = PartitionConnectionInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_test_execution_service_proto_msgTypes[9]
+		mi := &file_test_execution_service_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -615,7 +1393,7 @@ func (x *PartitionConnectionInfo) String() string {
 func (*PartitionConnectionInfo) ProtoMessage() {}
 
 func (x *PartitionConnectionInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_test_execution_service_proto_msgTypes[9]
+	mi := &file_test_execution_service_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -628,7 +1406,7 @@ func (x *PartitionConnectionInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PartitionConnectionInfo.ProtoReflect.Descriptor instead.
 func (*PartitionConnectionInfo) Descriptor() ([]byte, []int) {
-	return file_test_execution_service_proto_rawDescGZIP(), []int{9}
+	return file_test_execution_service_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *PartitionConnectionInfo) GetIsBlocked() bool {
@@ -688,7 +1466,15 @@ var file_test_execution_service_proto_rawDesc = []byte{
 	0x61, 0x74, 0x68, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76,
 	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xcb, 0x05, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x35, 0x0a, 0x16, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x68, 0x61, 0x72, 0x65, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x41, 0x72, 0x67, 0x73, 0x12,
+	0x1b, 0x0a, 0x09, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x49, 0x64, 0x22, 0x47, 0x0a, 0x1a,
+	0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x56, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x64, 0x69, 0x72, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x44, 0x69,
+	0x72, 0x70, 0x61, 0x74, 0x68, 0x22, 0xd8, 0x09, 0x0a, 0x10, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53,
 	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65,
 	0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
 	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x6f, 0x63,
@@ -720,127 +1506,268 @@ var file_test_execution_service_proto_rawDesc = []byte{
 	0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x70, 0x61,
 	0x74, 0x68, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x1a, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x41,
 	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x70,
-	0x61, 0x74, 0x68, 0x73, 0x1a, 0x3c, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x64, 0x50, 0x6f, 0x72, 0x74,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
-	0x38, 0x01, 0x1a, 0x40, 0x0a, 0x12, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x76, 0x56,
-	0x61, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4d, 0x0a, 0x1f, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x41, 0x72, 0x74,
-	0x69, 0x66, 0x61, 0x63, 0x74, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x70, 0x61, 0x74,
-	0x68, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
-	0x02, 0x38, 0x01, 0x22, 0x77, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x43, 0x0a, 0x1e, 0x63, 0x6f, 0x6e, 0x74, 0x61,
-	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
-	0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x1b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x6f, 0x70, 0x54, 0x69,
-	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0xa7, 0x04, 0x0a,
-	0x0f, 0x52, 0x65, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x73,
-	0x12, 0x68, 0x0a, 0x12, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e, 0x61,
-	0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69,
-	0x2e, 0x52, 0x65, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x73,
-	0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
-	0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x71, 0x0a, 0x15, 0x70, 0x61,
-	0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x61, 0x70, 0x69, 0x5f,
-	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65,
-	0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x73, 0x2e, 0x50, 0x61,
-	0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x14, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x59, 0x0a,
-	0x12, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x70, 0x69, 0x5f,
-	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61,
-	0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x11, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x6a, 0x0a, 0x16, 0x50, 0x61, 0x72, 0x74,
-	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
-	0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x70, 0x0a, 0x19, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
-	0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x3d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb2, 0x01, 0x0a, 0x11, 0x50, 0x61, 0x72, 0x74, 0x69,
-	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x5c, 0x0a, 0x0e,
-	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61,
-	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
-	0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76, 0x69,
-	0x63, 0x65, 0x49, 0x64, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x53, 0x65, 0x74, 0x1a, 0x3f, 0x0a, 0x11, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xeb, 0x01, 0x0a, 0x14,
-	0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x64, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b, 0x2e,
+	0x61, 0x74, 0x68, 0x73, 0x12, 0x4a, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
 	0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70,
-	0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65,
-	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x63, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x6d, 0x0a, 0x13, 0x43, 0x6f,
-	0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x6e, 0x74, 0x72,
+	0x69, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73,
+	0x52, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73,
+	0x12, 0x83, 0x01, 0x0a, 0x1c, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x76, 0x6f, 0x6c, 0x75,
+	0x6d, 0x65, 0x5f, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x70, 0x61, 0x74, 0x68,
+	0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x42, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x72,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x67, 0x73, 0x2e, 0x53, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69,
+	0x72, 0x70, 0x61, 0x74, 0x68, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x19, 0x73, 0x68, 0x61,
+	0x72, 0x65, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69,
+	0x72, 0x70, 0x61, 0x74, 0x68, 0x73, 0x12, 0x96, 0x01, 0x0a, 0x23, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x5f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x5f, 0x61, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x5f,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x48, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x67, 0x73, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x46,
+	0x69, 0x6c, 0x65, 0x73, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x4d, 0x6f, 0x75, 0x6e,
+	0x74, 0x44, 0x69, 0x72, 0x70, 0x61, 0x74, 0x68, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x1f,
+	0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x70, 0x61, 0x74, 0x68, 0x73, 0x1a,
+	0x3c, 0x0a, 0x0e, 0x55, 0x73, 0x65, 0x64, 0x50, 0x6f, 0x72, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72,
 	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x40, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
-	0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e,
-	0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x38, 0x0a, 0x17, 0x50, 0x61, 0x72,
-	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
-	0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x42, 0x6c, 0x6f, 0x63,
-	0x6b, 0x65, 0x64, 0x32, 0xa5, 0x04, 0x0a, 0x14, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63,
-	0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x56, 0x0a, 0x14,
-	0x47, 0x65, 0x74, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
-	0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x24, 0x2e, 0x61,
-	0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69,
-	0x2e, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
-	0x66, 0x6f, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x15, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72,
-	0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c, 0x2e,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x40, 0x0a,
+	0x12, 0x44, 0x6f, 0x63, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x76, 0x56, 0x61, 0x72, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x4d, 0x0a, 0x1f, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74,
+	0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x70, 0x61, 0x74, 0x68, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4c,
+	0x0a, 0x1e, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x4d, 0x6f,
+	0x75, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x70, 0x61, 0x74, 0x68, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x52, 0x0a, 0x24,
+	0x4c, 0x6f, 0x63, 0x61, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61,
+	0x63, 0x74, 0x4d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x69, 0x72, 0x70, 0x61, 0x74, 0x68, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x84, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x70, 0x75, 0x5f, 0x6d, 0x69, 0x6c, 0x6c, 0x69,
+	0x63, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63, 0x70, 0x75,
+	0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x63, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x69, 0x64, 0x73,
+	0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x70, 0x69,
+	0x64, 0x73, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x8c, 0x01, 0x0a, 0x1f, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x41, 0x72, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x4a, 0x0a, 0x0f, 0x72, 0x65,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x77, 0x0a, 0x11, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x43, 0x0a, 0x1e, 0x63, 0x6f,
+	0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x74, 0x6f, 0x70, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x1b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x6f,
+	0x70, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22,
+	0x60, 0x0a, 0x16, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x45, 0x78, 0x69, 0x74, 0x41, 0x72, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72,
+	0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x22, 0x39, 0x0a, 0x1a, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x45, 0x78, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x53, 0x0a, 0x0f,
+	0x45, 0x78, 0x65, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x41, 0x72, 0x67, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12, 0x21,
+	0x0a, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x41, 0x72, 0x67,
+	0x73, 0x22, 0x51, 0x0a, 0x13, 0x45, 0x78, 0x65, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x78, 0x69, 0x74,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69,
+	0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x67, 0x5f, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6c, 0x6f, 0x67, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x22, 0x33, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x41, 0x72, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x2c, 0x0a, 0x16, 0x47, 0x65, 0x74,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x6c, 0x6f, 0x67, 0x73, 0x22, 0x33, 0x0a, 0x12, 0x49, 0x6e, 0x73, 0x70, 0x65,
+	0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x67, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x22, 0x3b, 0x0a, 0x16,
+	0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x69, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x69, 0x6e,
+	0x73, 0x70, 0x65, 0x63, 0x74, 0x4a, 0x73, 0x6f, 0x6e, 0x22, 0x5b, 0x0a, 0x13, 0x43, 0x6f, 0x70,
+	0x79, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x67, 0x73,
+	0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x12,
+	0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x70, 0x61, 0x74,
+	0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e,
+	0x65, 0x72, 0x50, 0x61, 0x74, 0x68, 0x22, 0x55, 0x0a, 0x17, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x72,
+	0x6f, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3a, 0x0a, 0x19, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x5f, 0x72, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x66, 0x69, 0x6c, 0x65, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x17, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x65, 0x6c,
+	0x61, 0x74, 0x69, 0x76, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x70, 0x61, 0x74, 0x68, 0x22, 0xa7, 0x04,
+	0x0a, 0x0f, 0x52, 0x65, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67,
+	0x73, 0x12, 0x68, 0x0a, 0x12, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x39, 0x2e,
+	0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70,
+	0x69, 0x2e, 0x52, 0x65, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67,
+	0x73, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x71, 0x0a, 0x15, 0x70,
+	0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x61, 0x70, 0x69,
+	0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52,
+	0x65, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x73, 0x2e, 0x50,
+	0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x14, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x59,
+	0x0a, 0x12, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x5f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x70, 0x69,
+	0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50,
+	0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x11, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x6a, 0x0a, 0x16, 0x50, 0x61, 0x72,
+	0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x3a, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x70, 0x0a, 0x19, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x3d, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x27, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xb2, 0x01, 0x0a, 0x11, 0x50, 0x61, 0x72, 0x74,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x12, 0x5c, 0x0a,
+	0x0e, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x5f, 0x73, 0x65, 0x74, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x36, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74,
+	0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x49, 0x64, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0c, 0x73,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x53, 0x65, 0x74, 0x1a, 0x3f, 0x0a, 0x11, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x49, 0x64, 0x53, 0x65, 0x74, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xeb, 0x01, 0x0a,
+	0x14, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x64, 0x0a, 0x0f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3b,
+	0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61,
+	0x70, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0e, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x1a, 0x6d, 0x0a, 0x13, 0x43,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x40, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x38, 0x0a, 0x17, 0x50, 0x61,
+	0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x73, 0x5f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x69, 0x73, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x65, 0x64, 0x32, 0x88, 0x0a, 0x0a, 0x14, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x56, 0x0a,
+	0x14, 0x47, 0x65, 0x74, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x24, 0x2e,
 	0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70,
-	0x69, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78,
-	0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f,
-	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x67, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
-	0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63,
-	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x67,
-	0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x67, 0x73,
-	0x1a, 0x2a, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
-	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4d,
-	0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x23,
+	0x69, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x49,
+	0x6e, 0x66, 0x6f, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x15, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65,
+	0x72, 0x54, 0x65, 0x73, 0x74, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2c,
 	0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61,
-	0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41,
+	0x70, 0x69, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x54, 0x65, 0x73, 0x74, 0x45,
+	0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x16, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x67, 0x0a, 0x0f, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x5f,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65,
+	0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x67,
+	0x73, 0x1a, 0x2a, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x70, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x56,
+	0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x29, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x41, 0x72, 0x67, 0x73,
+	0x1a, 0x2d, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72,
+	0x5f, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x4d, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x23, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x53, 0x74, 0x61, 0x72, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00,
+	0x12, 0x6b, 0x0a, 0x1b, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12,
+	0x32, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x41,
 	0x72, 0x67, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
 	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4f, 0x0a,
 	0x0d, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x24,
 	0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61,
 	0x70, 0x69, 0x2e, 0x52, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
 	0x41, 0x72, 0x67, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x4b,
-	0x0a, 0x0b, 0x52, 0x65, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e,
-	0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70,
-	0x69, 0x2e, 0x52, 0x65, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x72, 0x67,
-	0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x12, 0x70,
+	0x0a, 0x12, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x45, 0x78, 0x69, 0x74, 0x12, 0x29, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x45, 0x78, 0x69, 0x74, 0x41, 0x72, 0x67, 0x73, 0x1a,
+	0x2d, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x45, 0x78, 0x69, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x5b, 0x0a, 0x0b, 0x45, 0x78, 0x65, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
+	0x22, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x41,
+	0x72, 0x67, 0x73, 0x1a, 0x26, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69,
+	0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x45, 0x78, 0x65, 0x63, 0x43, 0x6f, 0x6d, 0x6d,
+	0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x64, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x12,
+	0x25, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f,
+	0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x6f,
+	0x67, 0x73, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x29, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e,
+	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x64, 0x0a, 0x0e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x25, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74,
+	0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63,
+	0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x29, 0x2e, 0x61,
+	0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69,
+	0x2e, 0x49, 0x6e, 0x73, 0x70, 0x65, 0x63, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x67, 0x0a, 0x0f, 0x43, 0x6f, 0x70,
+	0x79, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x26, 0x2e, 0x61,
+	0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69,
+	0x2e, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x72, 0x6f, 0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
+	0x41, 0x72, 0x67, 0x73, 0x1a, 0x2a, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x43, 0x6f, 0x70, 0x79, 0x46, 0x72, 0x6f,
+	0x6d, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0b, 0x52, 0x65, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x22, 0x2e, 0x61, 0x70, 0x69, 0x5f, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65,
+	0x72, 0x5f, 0x61, 0x70, 0x69, 0x2e, 0x52, 0x65, 0x70, 0x61, 0x72, 0x74, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x41, 0x72, 0x67, 0x73, 0x1a, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x00, 0x62,
+	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -855,60 +1782,94 @@ func file_test_execution_service_proto_rawDescGZIP() []byte {
 	return file_test_execution_service_proto_rawDescData
 }
 
-var file_test_execution_service_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
+var file_test_execution_service_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
 var file_test_execution_service_proto_goTypes = []interface{}{
-	(*TestExecutionInfo)(nil),         // 0: api_container_api.TestExecutionInfo
-	(*RegisterTestExecutionArgs)(nil), // 1: api_container_api.RegisterTestExecutionArgs
-	(*RegisterServiceArgs)(nil),       // 2: api_container_api.RegisterServiceArgs
-	(*RegisterServiceResponse)(nil),   // 3: api_container_api.RegisterServiceResponse
-	(*StartServiceArgs)(nil),          // 4: api_container_api.StartServiceArgs
-	(*RemoveServiceArgs)(nil),         // 5: api_container_api.RemoveServiceArgs
-	(*RepartitionArgs)(nil),           // 6: api_container_api.RepartitionArgs
-	(*PartitionServices)(nil),         // 7: api_container_api.PartitionServices
-	(*PartitionConnections)(nil),      // 8: api_container_api.PartitionConnections
-	(*PartitionConnectionInfo)(nil),   // 9: api_container_api.PartitionConnectionInfo
-	nil,                               // 10: api_container_api.RegisterServiceArgs.FilesToGenerateEntry
-	nil,                               // 11: api_container_api.RegisterServiceResponse.GeneratedFilesRelativeFilepathsEntry
-	nil,                               // 12: api_container_api.StartServiceArgs.UsedPortsEntry
-	nil,                               // 13: api_container_api.StartServiceArgs.DockerEnvVarsEntry
-	nil,                               // 14: api_container_api.StartServiceArgs.FilesArtifactMountDirpathsEntry
-	nil,                               // 15: api_container_api.RepartitionArgs.PartitionServicesEntry
-	nil,                               // 16: api_container_api.RepartitionArgs.PartitionConnectionsEntry
-	nil,                               // 17: api_container_api.PartitionServices.ServiceIdSetEntry
-	nil,                               // 18: api_container_api.PartitionConnections.ConnectionInfoEntry
-	(*emptypb.Empty)(nil),             // 19: google.protobuf.Empty
+	(*TestExecutionInfo)(nil),               // 0: api_container_api.TestExecutionInfo
+	(*RegisterTestExecutionArgs)(nil),       // 1: api_container_api.RegisterTestExecutionArgs
+	(*RegisterServiceArgs)(nil),             // 2: api_container_api.RegisterServiceArgs
+	(*RegisterServiceResponse)(nil),         // 3: api_container_api.RegisterServiceResponse
+	(*CreateSharedVolumeArgs)(nil),          // 4: api_container_api.CreateSharedVolumeArgs
+	(*CreateSharedVolumeResponse)(nil),      // 5: api_container_api.CreateSharedVolumeResponse
+	(*StartServiceArgs)(nil),                // 6: api_container_api.StartServiceArgs
+	(*ResourceLimits)(nil),                  // 7: api_container_api.ResourceLimits
+	(*UpdateServiceResourceLimitsArgs)(nil), // 8: api_container_api.UpdateServiceResourceLimitsArgs
+	(*RemoveServiceArgs)(nil),               // 9: api_container_api.RemoveServiceArgs
+	(*WaitForServiceExitArgs)(nil),          // 10: api_container_api.WaitForServiceExitArgs
+	(*WaitForServiceExitResponse)(nil),      // 11: api_container_api.WaitForServiceExitResponse
+	(*ExecCommandArgs)(nil),                 // 12: api_container_api.ExecCommandArgs
+	(*ExecCommandResponse)(nil),             // 13: api_container_api.ExecCommandResponse
+	(*GetServiceLogsArgs)(nil),              // 14: api_container_api.GetServiceLogsArgs
+	(*GetServiceLogsResponse)(nil),          // 15: api_container_api.GetServiceLogsResponse
+	(*InspectServiceArgs)(nil),              // 16: api_container_api.InspectServiceArgs
+	(*InspectServiceResponse)(nil),          // 17: api_container_api.InspectServiceResponse
+	(*CopyFromServiceArgs)(nil),             // 18: api_container_api.CopyFromServiceArgs
+	(*CopyFromServiceResponse)(nil),         // 19: api_container_api.CopyFromServiceResponse
+	(*RepartitionArgs)(nil),                 // 20: api_container_api.RepartitionArgs
+	(*PartitionServices)(nil),               // 21: api_container_api.PartitionServices
+	(*PartitionConnections)(nil),            // 22: api_container_api.PartitionConnections
+	(*PartitionConnectionInfo)(nil),         // 23: api_container_api.PartitionConnectionInfo
+	nil,                                     // 24: api_container_api.RegisterServiceArgs.FilesToGenerateEntry
+	nil,                                     // 25: api_container_api.RegisterServiceResponse.GeneratedFilesRelativeFilepathsEntry
+	nil,                                     // 26: api_container_api.StartServiceArgs.UsedPortsEntry
+	nil,                                     // 27: api_container_api.StartServiceArgs.DockerEnvVarsEntry
+	nil,                                     // 28: api_container_api.StartServiceArgs.FilesArtifactMountDirpathsEntry
+	nil,                                     // 29: api_container_api.StartServiceArgs.SharedVolumeMountDirpathsEntry
+	nil,                                     // 30: api_container_api.StartServiceArgs.LocalFilesArtifactMountDirpathsEntry
+	nil,                                     // 31: api_container_api.RepartitionArgs.PartitionServicesEntry
+	nil,                                     // 32: api_container_api.RepartitionArgs.PartitionConnectionsEntry
+	nil,                                     // 33: api_container_api.PartitionServices.ServiceIdSetEntry
+	nil,                                     // 34: api_container_api.PartitionConnections.ConnectionInfoEntry
+	(*emptypb.Empty)(nil),                   // 35: google.protobuf.Empty
 }
 var file_test_execution_service_proto_depIdxs = []int32{
-	10, // 0: api_container_api.RegisterServiceArgs.files_to_generate:type_name -> api_container_api.RegisterServiceArgs.FilesToGenerateEntry
-	11, // 1: api_container_api.RegisterServiceResponse.generated_files_relative_filepaths:type_name -> api_container_api.RegisterServiceResponse.GeneratedFilesRelativeFilepathsEntry
-	12, // 2: api_container_api.StartServiceArgs.used_ports:type_name -> api_container_api.StartServiceArgs.UsedPortsEntry
-	13, // 3: api_container_api.StartServiceArgs.docker_env_vars:type_name -> api_container_api.StartServiceArgs.DockerEnvVarsEntry
-	14, // 4: api_container_api.StartServiceArgs.files_artifact_mount_dirpaths:type_name -> api_container_api.StartServiceArgs.FilesArtifactMountDirpathsEntry
-	15, // 5: api_container_api.RepartitionArgs.partition_services:type_name -> api_container_api.RepartitionArgs.PartitionServicesEntry
-	16, // 6: api_container_api.RepartitionArgs.partition_connections:type_name -> api_container_api.RepartitionArgs.PartitionConnectionsEntry
-	9,  // 7: api_container_api.RepartitionArgs.default_connection:type_name -> api_container_api.PartitionConnectionInfo
-	17, // 8: api_container_api.PartitionServices.service_id_set:type_name -> api_container_api.PartitionServices.ServiceIdSetEntry
-	18, // 9: api_container_api.PartitionConnections.connection_info:type_name -> api_container_api.PartitionConnections.ConnectionInfoEntry
-	7,  // 10: api_container_api.RepartitionArgs.PartitionServicesEntry.value:type_name -> api_container_api.PartitionServices
-	8,  // 11: api_container_api.RepartitionArgs.PartitionConnectionsEntry.value:type_name -> api_container_api.PartitionConnections
-	9,  // 12: api_container_api.PartitionConnections.ConnectionInfoEntry.value:type_name -> api_container_api.PartitionConnectionInfo
-	19, // 13: api_container_api.TestExecutionService.GetTestExecutionInfo:input_type -> google.protobuf.Empty
-	1,  // 14: api_container_api.TestExecutionService.RegisterTestExecution:input_type -> api_container_api.RegisterTestExecutionArgs
-	2,  // 15: api_container_api.TestExecutionService.RegisterService:input_type -> api_container_api.RegisterServiceArgs
-	4,  // 16: api_container_api.TestExecutionService.StartService:input_type -> api_container_api.StartServiceArgs
-	5,  // 17: api_container_api.TestExecutionService.RemoveService:input_type -> api_container_api.RemoveServiceArgs
-	6,  // 18: api_container_api.TestExecutionService.Repartition:input_type -> api_container_api.RepartitionArgs
-	0,  // 19: api_container_api.TestExecutionService.GetTestExecutionInfo:output_type -> api_container_api.TestExecutionInfo
-	19, // 20: api_container_api.TestExecutionService.RegisterTestExecution:output_type -> google.protobuf.Empty
-	3,  // 21: api_container_api.TestExecutionService.RegisterService:output_type -> api_container_api.RegisterServiceResponse
-	19, // 22: api_container_api.TestExecutionService.StartService:output_type -> google.protobuf.Empty
-	19, // 23: api_container_api.TestExecutionService.RemoveService:output_type -> google.protobuf.Empty
-	19, // 24: api_container_api.TestExecutionService.Repartition:output_type -> google.protobuf.Empty
-	19, // [19:25] is the sub-list for method output_type
-	13, // [13:19] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	24, // 0: api_container_api.RegisterServiceArgs.files_to_generate:type_name -> api_container_api.RegisterServiceArgs.FilesToGenerateEntry
+	25, // 1: api_container_api.RegisterServiceResponse.generated_files_relative_filepaths:type_name -> api_container_api.RegisterServiceResponse.GeneratedFilesRelativeFilepathsEntry
+	26, // 2: api_container_api.StartServiceArgs.used_ports:type_name -> api_container_api.StartServiceArgs.UsedPortsEntry
+	27, // 3: api_container_api.StartServiceArgs.docker_env_vars:type_name -> api_container_api.StartServiceArgs.DockerEnvVarsEntry
+	28, // 4: api_container_api.StartServiceArgs.files_artifact_mount_dirpaths:type_name -> api_container_api.StartServiceArgs.FilesArtifactMountDirpathsEntry
+	7,  // 5: api_container_api.StartServiceArgs.resource_limits:type_name -> api_container_api.ResourceLimits
+	29, // 6: api_container_api.StartServiceArgs.shared_volume_mount_dirpaths:type_name -> api_container_api.StartServiceArgs.SharedVolumeMountDirpathsEntry
+	30, // 7: api_container_api.StartServiceArgs.local_files_artifact_mount_dirpaths:type_name -> api_container_api.StartServiceArgs.LocalFilesArtifactMountDirpathsEntry
+	7,  // 8: api_container_api.UpdateServiceResourceLimitsArgs.resource_limits:type_name -> api_container_api.ResourceLimits
+	31, // 9: api_container_api.RepartitionArgs.partition_services:type_name -> api_container_api.RepartitionArgs.PartitionServicesEntry
+	32, // 10: api_container_api.RepartitionArgs.partition_connections:type_name -> api_container_api.RepartitionArgs.PartitionConnectionsEntry
+	23, // 11: api_container_api.RepartitionArgs.default_connection:type_name -> api_container_api.PartitionConnectionInfo
+	33, // 12: api_container_api.PartitionServices.service_id_set:type_name -> api_container_api.PartitionServices.ServiceIdSetEntry
+	34, // 13: api_container_api.PartitionConnections.connection_info:type_name -> api_container_api.PartitionConnections.ConnectionInfoEntry
+	21, // 14: api_container_api.RepartitionArgs.PartitionServicesEntry.value:type_name -> api_container_api.PartitionServices
+	22, // 15: api_container_api.RepartitionArgs.PartitionConnectionsEntry.value:type_name -> api_container_api.PartitionConnections
+	23, // 16: api_container_api.PartitionConnections.ConnectionInfoEntry.value:type_name -> api_container_api.PartitionConnectionInfo
+	35, // 17: api_container_api.TestExecutionService.GetTestExecutionInfo:input_type -> google.protobuf.Empty
+	1,  // 18: api_container_api.TestExecutionService.RegisterTestExecution:input_type -> api_container_api.RegisterTestExecutionArgs
+	2,  // 19: api_container_api.TestExecutionService.RegisterService:input_type -> api_container_api.RegisterServiceArgs
+	4,  // 20: api_container_api.TestExecutionService.CreateSharedVolume:input_type -> api_container_api.CreateSharedVolumeArgs
+	6,  // 21: api_container_api.TestExecutionService.StartService:input_type -> api_container_api.StartServiceArgs
+	8,  // 22: api_container_api.TestExecutionService.UpdateServiceResourceLimits:input_type -> api_container_api.UpdateServiceResourceLimitsArgs
+	9,  // 23: api_container_api.TestExecutionService.RemoveService:input_type -> api_container_api.RemoveServiceArgs
+	10, // 24: api_container_api.TestExecutionService.WaitForServiceExit:input_type -> api_container_api.WaitForServiceExitArgs
+	12, // 25: api_container_api.TestExecutionService.ExecCommand:input_type -> api_container_api.ExecCommandArgs
+	14, // 26: api_container_api.TestExecutionService.GetServiceLogs:input_type -> api_container_api.GetServiceLogsArgs
+	16, // 27: api_container_api.TestExecutionService.InspectService:input_type -> api_container_api.InspectServiceArgs
+	18, // 28: api_container_api.TestExecutionService.CopyFromService:input_type -> api_container_api.CopyFromServiceArgs
+	20, // 29: api_container_api.TestExecutionService.Repartition:input_type -> api_container_api.RepartitionArgs
+	0,  // 30: api_container_api.TestExecutionService.GetTestExecutionInfo:output_type -> api_container_api.TestExecutionInfo
+	35, // 31: api_container_api.TestExecutionService.RegisterTestExecution:output_type -> google.protobuf.Empty
+	3,  // 32: api_container_api.TestExecutionService.RegisterService:output_type -> api_container_api.RegisterServiceResponse
+	5,  // 33: api_container_api.TestExecutionService.CreateSharedVolume:output_type -> api_container_api.CreateSharedVolumeResponse
+	35, // 34: api_container_api.TestExecutionService.StartService:output_type -> google.protobuf.Empty
+	35, // 35: api_container_api.TestExecutionService.UpdateServiceResourceLimits:output_type -> google.protobuf.Empty
+	35, // 36: api_container_api.TestExecutionService.RemoveService:output_type -> google.protobuf.Empty
+	11, // 37: api_container_api.TestExecutionService.WaitForServiceExit:output_type -> api_container_api.WaitForServiceExitResponse
+	13, // 38: api_container_api.TestExecutionService.ExecCommand:output_type -> api_container_api.ExecCommandResponse
+	15, // 39: api_container_api.TestExecutionService.GetServiceLogs:output_type -> api_container_api.GetServiceLogsResponse
+	17, // 40: api_container_api.TestExecutionService.InspectService:output_type -> api_container_api.InspectServiceResponse
+	19, // 41: api_container_api.TestExecutionService.CopyFromService:output_type -> api_container_api.CopyFromServiceResponse
+	35, // 42: api_container_api.TestExecutionService.Repartition:output_type -> google.protobuf.Empty
+	30, // [30:43] is the sub-list for method output_type
+	17, // [17:30] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_test_execution_service_proto_init() }
@@ -929,8 +1890,80 @@ func file_test_execution_service_proto_init() {
 				return nil
 			}
 		}
-		file_test_execution_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterTestExecutionArgs); i {
+		file_test_execution_service_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterTestExecutionArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterServiceArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegisterServiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSharedVolumeArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateSharedVolumeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StartServiceArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResourceLimits); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -941,8 +1974,8 @@ func file_test_execution_service_proto_init() {
 				return nil
 			}
 		}
-		file_test_execution_service_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterServiceArgs); i {
+		file_test_execution_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateServiceResourceLimitsArgs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -953,8 +1986,8 @@ func file_test_execution_service_proto_init() {
 				return nil
 			}
 		}
-		file_test_execution_service_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RegisterServiceResponse); i {
+		file_test_execution_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RemoveServiceArgs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -965,8 +1998,8 @@ func file_test_execution_service_proto_init() {
 				return nil
 			}
 		}
-		file_test_execution_service_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*StartServiceArgs); i {
+		file_test_execution_service_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WaitForServiceExitArgs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -977,8 +2010,8 @@ func file_test_execution_service_proto_init() {
 				return nil
 			}
 		}
-		file_test_execution_service_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RemoveServiceArgs); i {
+		file_test_execution_service_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WaitForServiceExitResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -989,7 +2022,103 @@ func file_test_execution_service_proto_init() {
 				return nil
 			}
 		}
-		file_test_execution_service_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+		file_test_execution_service_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecCommandArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExecCommandResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetServiceLogsArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetServiceLogsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InspectServiceArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InspectServiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyFromServiceArgs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CopyFromServiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_test_execution_service_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*RepartitionArgs); i {
 			case 0:
 				return &v.state
@@ -1001,7 +2130,7 @@ func file_test_execution_service_proto_init() {
 				return nil
 			}
 		}
-		file_test_execution_service_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+		file_test_execution_service_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PartitionServices); i {
 			case 0:
 				return &v.state
@@ -1013,7 +2142,7 @@ func file_test_execution_service_proto_init() {
 				return nil
 			}
 		}
-		file_test_execution_service_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+		file_test_execution_service_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PartitionConnections); i {
 			case 0:
 				return &v.state
@@ -1025,7 +2154,7 @@ func file_test_execution_service_proto_init() {
 				return nil
 			}
 		}
-		file_test_execution_service_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+		file_test_execution_service_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PartitionConnectionInfo); i {
 			case 0:
 				return &v.state
@@ -1044,7 +2173,7 @@ func file_test_execution_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_test_execution_service_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   19,
+			NumMessages:   35,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -1078,10 +2207,26 @@ type TestExecutionServiceClient interface {
 	RegisterTestExecution(ctx context.Context, in *RegisterTestExecutionArgs, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Registers a service with the API container but doesn't start the container for it
 	RegisterService(ctx context.Context, in *RegisterServiceArgs, opts ...grpc.CallOption) (*RegisterServiceResponse, error)
+	// Creates a volume that can be mounted into multiple services, backed by a directory on the suite execution volume so
+	//  that the testsuite can read & write it too
+	CreateSharedVolume(ctx context.Context, in *CreateSharedVolumeArgs, opts ...grpc.CallOption) (*CreateSharedVolumeResponse, error)
 	// Starts a previously-registered service by creating a Docker container for it
 	StartService(ctx context.Context, in *StartServiceArgs, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Changes the resource limits of a running service's container, e.g. to simulate resource starvation
+	UpdateServiceResourceLimits(ctx context.Context, in *UpdateServiceResourceLimitsArgs, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// Instructs the API container to remove the given service
 	RemoveService(ctx context.Context, in *RemoveServiceArgs, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Blocks until the container of the given service exits (or the timeout is hit), returning the container's exit code
+	WaitForServiceExit(ctx context.Context, in *WaitForServiceExitArgs, opts ...grpc.CallOption) (*WaitForServiceExitResponse, error)
+	// Executes a command inside the running container of the given service, returning its exit code & output
+	ExecCommand(ctx context.Context, in *ExecCommandArgs, opts ...grpc.CallOption) (*ExecCommandResponse, error)
+	// Gets the logs that the container of the given service has output so far
+	GetServiceLogs(ctx context.Context, in *GetServiceLogsArgs, opts ...grpc.CallOption) (*GetServiceLogsResponse, error)
+	// Gets the low-level information about the container of the given service, as reported by the container engine
+	InspectService(ctx context.Context, in *InspectServiceArgs, opts ...grpc.CallOption) (*InspectServiceResponse, error)
+	// Copies a path inside the container of the given service to the suite execution volume, as a TAR archive
+	// This works even if the container has already exited
+	CopyFromService(ctx context.Context, in *CopyFromServiceArgs, opts ...grpc.CallOption) (*CopyFromServiceResponse, error)
 	// Instructs the API container to repartition the test network
 	Repartition(ctx context.Context, in *RepartitionArgs, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
@@ -1121,6 +2266,15 @@ func (c *testExecutionServiceClient) RegisterService(ctx context.Context, in *Re
 	return out, nil
 }
 
+func (c *testExecutionServiceClient) CreateSharedVolume(ctx context.Context, in *CreateSharedVolumeArgs, opts ...grpc.CallOption) (*CreateSharedVolumeResponse, error) {
+	out := new(CreateSharedVolumeResponse)
+	err := c.cc.Invoke(ctx, "/api_container_api.TestExecutionService/CreateSharedVolume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *testExecutionServiceClient) StartService(ctx context.Context, in *StartServiceArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/api_container_api.TestExecutionService/StartService", in, out, opts...)
@@ -1130,6 +2284,15 @@ func (c *testExecutionServiceClient) StartService(ctx context.Context, in *Start
 	return out, nil
 }
 
+func (c *testExecutionServiceClient) UpdateServiceResourceLimits(ctx context.Context, in *UpdateServiceResourceLimitsArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, "/api_container_api.TestExecutionService/UpdateServiceResourceLimits", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *testExecutionServiceClient) RemoveService(ctx context.Context, in *RemoveServiceArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/api_container_api.TestExecutionService/RemoveService", in, out, opts...)
@@ -1139,6 +2302,51 @@ func (c *testExecutionServiceClient) RemoveService(ctx context.Context, in *Remo
 	return out, nil
 }
 
+func (c *testExecutionServiceClient) WaitForServiceExit(ctx context.Context, in *WaitForServiceExitArgs, opts ...grpc.CallOption) (*WaitForServiceExitResponse, error) {
+	out := new(WaitForServiceExitResponse)
+	err := c.cc.Invoke(ctx, "/api_container_api.TestExecutionService/WaitForServiceExit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testExecutionServiceClient) ExecCommand(ctx context.Context, in *ExecCommandArgs, opts ...grpc.CallOption) (*ExecCommandResponse, error) {
+	out := new(ExecCommandResponse)
+	err := c.cc.Invoke(ctx, "/api_container_api.TestExecutionService/ExecCommand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testExecutionServiceClient) GetServiceLogs(ctx context.Context, in *GetServiceLogsArgs, opts ...grpc.CallOption) (*GetServiceLogsResponse, error) {
+	out := new(GetServiceLogsResponse)
+	err := c.cc.Invoke(ctx, "/api_container_api.TestExecutionService/GetServiceLogs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testExecutionServiceClient) InspectService(ctx context.Context, in *InspectServiceArgs, opts ...grpc.CallOption) (*InspectServiceResponse, error) {
+	out := new(InspectServiceResponse)
+	err := c.cc.Invoke(ctx, "/api_container_api.TestExecutionService/InspectService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *testExecutionServiceClient) CopyFromService(ctx context.Context, in *CopyFromServiceArgs, opts ...grpc.CallOption) (*CopyFromServiceResponse, error) {
+	out := new(CopyFromServiceResponse)
+	err := c.cc.Invoke(ctx, "/api_container_api.TestExecutionService/CopyFromService", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *testExecutionServiceClient) Repartition(ctx context.Context, in *RepartitionArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
 	err := c.cc.Invoke(ctx, "/api_container_api.TestExecutionService/Repartition", in, out, opts...)
@@ -1158,10 +2366,26 @@ type TestExecutionServiceServer interface {
 	RegisterTestExecution(context.Context, *RegisterTestExecutionArgs) (*emptypb.Empty, error)
 	// Registers a service with the API container but doesn't start the container for it
 	RegisterService(context.Context, *RegisterServiceArgs) (*RegisterServiceResponse, error)
+	// Creates a volume that can be mounted into multiple services, backed by a directory on the suite execution volume so
+	//  that the testsuite can read & write it too
+	CreateSharedVolume(context.Context, *CreateSharedVolumeArgs) (*CreateSharedVolumeResponse, error)
 	// Starts a previously-registered service by creating a Docker container for it
 	StartService(context.Context, *StartServiceArgs) (*emptypb.Empty, error)
+	// Changes the resource limits of a running service's container, e.g. to simulate resource starvation
+	UpdateServiceResourceLimits(context.Context, *UpdateServiceResourceLimitsArgs) (*emptypb.Empty, error)
 	// Instructs the API container to remove the given service
 	RemoveService(context.Context, *RemoveServiceArgs) (*emptypb.Empty, error)
+	// Blocks until the container of the given service exits (or the timeout is hit), returning the container's exit code
+	WaitForServiceExit(context.Context, *WaitForServiceExitArgs) (*WaitForServiceExitResponse, error)
+	// Executes a command inside the running container of the given service, returning its exit code & output
+	ExecCommand(context.Context, *ExecCommandArgs) (*ExecCommandResponse, error)
+	// Gets the logs that the container of the given service has output so far
+	GetServiceLogs(context.Context, *GetServiceLogsArgs) (*GetServiceLogsResponse, error)
+	// Gets the low-level information about the container of the given service, as reported by the container engine
+	InspectService(context.Context, *InspectServiceArgs) (*InspectServiceResponse, error)
+	// Copies a path inside the container of the given service to the suite execution volume, as a TAR archive
+	// This works even if the container has already exited
+	CopyFromService(context.Context, *CopyFromServiceArgs) (*CopyFromServiceResponse, error)
 	// Instructs the API container to repartition the test network
 	Repartition(context.Context, *RepartitionArgs) (*emptypb.Empty, error)
 }
@@ -1179,12 +2403,33 @@ func (*UnimplementedTestExecutionServiceServer) RegisterTestExecution(context.Co
 func (*UnimplementedTestExecutionServiceServer) RegisterService(context.Context, *RegisterServiceArgs) (*RegisterServiceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterService not implemented")
 }
+func (*UnimplementedTestExecutionServiceServer) CreateSharedVolume(context.Context, *CreateSharedVolumeArgs) (*CreateSharedVolumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSharedVolume not implemented")
+}
 func (*UnimplementedTestExecutionServiceServer) StartService(context.Context, *StartServiceArgs) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartService not implemented")
 }
+func (*UnimplementedTestExecutionServiceServer) UpdateServiceResourceLimits(context.Context, *UpdateServiceResourceLimitsArgs) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateServiceResourceLimits not implemented")
+}
 func (*UnimplementedTestExecutionServiceServer) RemoveService(context.Context, *RemoveServiceArgs) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveService not implemented")
 }
+func (*UnimplementedTestExecutionServiceServer) WaitForServiceExit(context.Context, *WaitForServiceExitArgs) (*WaitForServiceExitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method WaitForServiceExit not implemented")
+}
+func (*UnimplementedTestExecutionServiceServer) ExecCommand(context.Context, *ExecCommandArgs) (*ExecCommandResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecCommand not implemented")
+}
+func (*UnimplementedTestExecutionServiceServer) GetServiceLogs(context.Context, *GetServiceLogsArgs) (*GetServiceLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceLogs not implemented")
+}
+func (*UnimplementedTestExecutionServiceServer) InspectService(context.Context, *InspectServiceArgs) (*InspectServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InspectService not implemented")
+}
+func (*UnimplementedTestExecutionServiceServer) CopyFromService(context.Context, *CopyFromServiceArgs) (*CopyFromServiceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CopyFromService not implemented")
+}
 func (*UnimplementedTestExecutionServiceServer) Repartition(context.Context, *RepartitionArgs) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Repartition not implemented")
 }
@@ -1247,6 +2492,24 @@ func _TestExecutionService_RegisterService_Handler(srv interface{}, ctx context.
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TestExecutionService_CreateSharedVolume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSharedVolumeArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestExecutionServiceServer).CreateSharedVolume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api_container_api.TestExecutionService/CreateSharedVolume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestExecutionServiceServer).CreateSharedVolume(ctx, req.(*CreateSharedVolumeArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TestExecutionService_StartService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartServiceArgs)
 	if err := dec(in); err != nil {
@@ -1265,6 +2528,24 @@ func _TestExecutionService_StartService_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TestExecutionService_UpdateServiceResourceLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateServiceResourceLimitsArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestExecutionServiceServer).UpdateServiceResourceLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api_container_api.TestExecutionService/UpdateServiceResourceLimits",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestExecutionServiceServer).UpdateServiceResourceLimits(ctx, req.(*UpdateServiceResourceLimitsArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TestExecutionService_RemoveService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RemoveServiceArgs)
 	if err := dec(in); err != nil {
@@ -1283,6 +2564,96 @@ func _TestExecutionService_RemoveService_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TestExecutionService_WaitForServiceExit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitForServiceExitArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestExecutionServiceServer).WaitForServiceExit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api_container_api.TestExecutionService/WaitForServiceExit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestExecutionServiceServer).WaitForServiceExit(ctx, req.(*WaitForServiceExitArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestExecutionService_ExecCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecCommandArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestExecutionServiceServer).ExecCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api_container_api.TestExecutionService/ExecCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestExecutionServiceServer).ExecCommand(ctx, req.(*ExecCommandArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestExecutionService_GetServiceLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceLogsArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestExecutionServiceServer).GetServiceLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api_container_api.TestExecutionService/GetServiceLogs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestExecutionServiceServer).GetServiceLogs(ctx, req.(*GetServiceLogsArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestExecutionService_InspectService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InspectServiceArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestExecutionServiceServer).InspectService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api_container_api.TestExecutionService/InspectService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestExecutionServiceServer).InspectService(ctx, req.(*InspectServiceArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TestExecutionService_CopyFromService_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CopyFromServiceArgs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TestExecutionServiceServer).CopyFromService(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api_container_api.TestExecutionService/CopyFromService",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TestExecutionServiceServer).CopyFromService(ctx, req.(*CopyFromServiceArgs))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TestExecutionService_Repartition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RepartitionArgs)
 	if err := dec(in); err != nil {
@@ -1317,14 +2688,42 @@ var _TestExecutionService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterService",
 			Handler:    _TestExecutionService_RegisterService_Handler,
 		},
+		{
+			MethodName: "CreateSharedVolume",
+			Handler:    _TestExecutionService_CreateSharedVolume_Handler,
+		},
 		{
 			MethodName: "StartService",
 			Handler:    _TestExecutionService_StartService_Handler,
 		},
+		{
+			MethodName: "UpdateServiceResourceLimits",
+			Handler:    _TestExecutionService_UpdateServiceResourceLimits_Handler,
+		},
 		{
 			MethodName: "RemoveService",
 			Handler:    _TestExecutionService_RemoveService_Handler,
 		},
+		{
+			MethodName: "WaitForServiceExit",
+			Handler:    _TestExecutionService_WaitForServiceExit_Handler,
+		},
+		{
+			MethodName: "ExecCommand",
+			Handler:    _TestExecutionService_ExecCommand_Handler,
+		},
+		{
+			MethodName: "GetServiceLogs",
+			Handler:    _TestExecutionService_GetServiceLogs_Handler,
+		},
+		{
+			MethodName: "InspectService",
+			Handler:    _TestExecutionService_InspectService_Handler,
+		},
+		{
+			MethodName: "CopyFromService",
+			Handler:    _TestExecutionService_CopyFromService_Handler,
+		},
 		{
 			MethodName: "Repartition",
 			Handler:    _TestExecutionService_Repartition_Handler,
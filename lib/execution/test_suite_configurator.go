@@ -5,7 +5,10 @@
 
 package execution
 
-import "github.com/kurtosis-tech/kurtosis-go/lib/testsuite"
+import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/testsuite"
+	"time"
+)
 
 // Implementations of this interface are responsible for initialzing the testsuite to a state
 //  where it can be run
@@ -31,3 +34,23 @@ type TestSuiteConfigurator interface {
 	 */
 	ParseParamsAndCreateSuite(paramsJsonStr string) (testsuite.TestSuite, error)
 }
+
+/*
+An optional interface that a TestSuiteConfigurator can also implement to enable debug mode, which is detected when the
+	testsuite is run
+ */
+type DebugModeTestSuiteConfigurator interface {
+	/*
+	This function should parse how long to keep a failed test's network alive for investigation from the custom
+		testsuite parameters JSON. A nonzero duration enables debug mode: when a test fails, the executor prints how
+		to reach each service and holds the network until the duration elapses or the testsuite receives an interrupt
+		signal.
+
+	Args:
+		paramsJsonStr: The same JSON-serialized custom params data that's passed to ParseParamsAndCreateSuite.
+
+	Returns:
+		The debug hold duration, or 0 to disable debug mode.
+	 */
+	ParseDebugHoldDuration(paramsJsonStr string) (time.Duration, error)
+}
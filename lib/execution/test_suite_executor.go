@@ -10,17 +10,40 @@ import (
 	"fmt"
 	"github.com/kurtosis-tech/kurtosis-go/lib/core_api/bindings"
 	"github.com/kurtosis-tech/kurtosis-go/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-go/lib/test_suite_docker_consts/test_suite_container_mountpoints"
 	"github.com/kurtosis-tech/kurtosis-go/lib/testsuite"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"os"
+	"os/signal"
+	"path"
+	"sort"
+	"strings"
+	"syscall"
 	"time"
 )
 
 const (
 	maxSuiteRegistrationRetries = 20
 	timeBetweenSuiteRegistrationRetries = 500 * time.Millisecond
+
+	// Name of the directory on the suite execution volume where diagnostics about failed tests will be captured
+	diagnosticsDirname = "diagnostics"
+
+	// Time reserved in the hard test timeout for capturing diagnostics about a failed test, which happens before the
+	//  debug hold starts
+	diagnosticsCaptureBudget = 2 * time.Minute
+)
+
+// Why a network held for debugging was released
+type debugHoldReleaseReason string
+
+const (
+	releasedBySignal debugHoldReleaseReason = "signal"
+	releasedByHoldDurationElapsing debugHoldReleaseReason = "hold duration elapsed"
+	releasedByContextCancellation debugHoldReleaseReason = "context cancelled"
 )
 
 type TestSuiteExecutor struct {
@@ -44,6 +67,11 @@ func (executor *TestSuiteExecutor) Run(ctx context.Context) error {
 		return stacktrace.Propagate(err, "An error occurred parsing the suite params JSON and creating the testsuite")
 	}
 
+	debugHoldDuration, err := getDebugHoldDuration(executor.configurator, executor.paramsJsonStr)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the debug hold duration")
+	}
+
 	// TODO SECURITY: Use HTTPS to ensure we're connecting to the real Kurtosis API servers
 	conn, err := grpc.Dial(executor.kurtosisApiSocket, grpc.WithInsecure())
 	if err != nil {
@@ -85,7 +113,7 @@ func (executor *TestSuiteExecutor) Run(ctx context.Context) error {
 		}
 		return nil
 	case bindings.SuiteAction_EXECUTE_TEST:
-		if err := runTestExecutionFlow(ctx, suite, conn); err != nil {
+		if err := runTestExecutionFlow(ctx, suite, conn, debugHoldDuration); err != nil {
 			return stacktrace.Propagate(err, "An error occurred running the test execution flow")
 		}
 		return nil
@@ -124,7 +152,7 @@ func runSerializeSuiteMetadataFlow(ctx context.Context, testsuite testsuite.Test
 	return nil
 }
 
-func runTestExecutionFlow(ctx context.Context, testsuite testsuite.TestSuite, conn *grpc.ClientConn) error {
+func runTestExecutionFlow(ctx context.Context, suite testsuite.TestSuite, conn *grpc.ClientConn, debugHoldDuration time.Duration) error {
 	executionClient := bindings.NewTestExecutionServiceClient(conn)
 	testExecutionInfo, err := executionClient.GetTestExecutionInfo(ctx, &emptypb.Empty{})
 	if err != nil {
@@ -132,7 +160,7 @@ func runTestExecutionFlow(ctx context.Context, testsuite testsuite.TestSuite, co
 	}
 	testName := testExecutionInfo.TestName
 
-	allTests := testsuite.GetTests()
+	allTests := suite.GetTests()
 	test, found := allTests[testName]
 	if !found {
 		return stacktrace.NewError(
@@ -143,7 +171,7 @@ func runTestExecutionFlow(ctx context.Context, testsuite testsuite.TestSuite, co
 
 	// Kick off a timer with the API in case there's an infinite loop in the user code that causes the test to hang forever
 	// TODO this should just be "register test execution started", since the API container already has the metadata
-	hardTestTimeout := test.GetExecutionTimeout() + test.GetSetupTeardownBuffer()
+	hardTestTimeout := getHardTestTimeout(test, debugHoldDuration)
 	hardTestTimeoutSeconds := uint64(hardTestTimeout.Seconds())
 	registerTestExecutionMessage := &bindings.RegisterTestExecutionArgs{TimeoutSeconds: hardTestTimeoutSeconds}
 	if _, err := executionClient.RegisterTestExecution(ctx, registerTestExecutionMessage); err != nil {
@@ -152,25 +180,52 @@ func runTestExecutionFlow(ctx context.Context, testsuite testsuite.TestSuite, co
 
 	testConfig := test.GetTestConfiguration()
 	filesArtifactUrls := testConfig.FilesArtifactUrls
+	localFilesArtifactPaths := testConfig.LocalFilesArtifacts
+	for filesArtifactId := range localFilesArtifactPaths {
+		if err := networks.ValidateLocalFilesArtifactId(filesArtifactId); err != nil {
+			return stacktrace.Propagate(err, "The test's TestConfiguration.LocalFilesArtifacts declares an invalid files artifact ID")
+		}
+		if _, found := filesArtifactUrls[filesArtifactId]; found {
+			return stacktrace.NewError(
+				"Files artifact '%v' is declared in both the test's TestConfiguration.FilesArtifactUrls and LocalFilesArtifacts",
+				filesArtifactId)
+		}
+	}
 
 	networkCtx := networks.NewNetworkContext(
 		executionClient,
-		filesArtifactUrls)
+		filesArtifactUrls,
+		localFilesArtifactPaths)
 
 	// TODO Also time out the setup with the API container rather than storing this locally
 	//  to reduce complexity inside the lib
 	logrus.Info("Setting up the test network...")
 	untypedNetwork, err := test.Setup(networkCtx)
 	if err != nil {
+		handleTestFailure(ctx, networkCtx, testName, testConfig, debugHoldDuration)
 		return stacktrace.Propagate(err, "An error occurred setting up the test network")
 	}
 	logrus.Info("Test network set up")
 
+	testCtx := testsuite.NewTestContext()
+	healthMonitorConfig := testConfig.HealthMonitorConfig
+	if healthMonitorConfig != nil {
+		var onUnexpectedDown func(err error)
+		if healthMonitorConfig.FailTestOnUnexpectedDown {
+			onUnexpectedDown = testCtx.FatalAsync
+		}
+		if _, err := networkCtx.StartHealthMonitor(healthMonitorConfig.PollInterval, onUnexpectedDown); err != nil {
+			handleTestFailure(ctx, networkCtx, testName, testConfig, debugHoldDuration)
+			return stacktrace.Propagate(err, "An error occurred starting the service health monitor")
+		}
+	}
+
 	logrus.Infof("Executing test '%v'...", testName)
-	testResultChan := make(chan error)
+	// Buffered so that the test goroutine can exit even if we've stopped listening because of a timeout or async failure
+	testResultChan := make(chan error, 1)
 
 	go func() {
-		testResultChan <- runTestInGoroutine(test, untypedNetwork)
+		testResultChan <- runTestInGoroutine(test, untypedNetwork, testCtx)
 	}()
 
 	// TODO Switch to registering the timeout with the API container rather than storing this locally
@@ -183,26 +238,191 @@ func runTestExecutionFlow(ctx context.Context, testsuite testsuite.TestSuite, co
 	case testResultErr = <- testResultChan:
 		logrus.Tracef("Test returned result before timeout: %v", testResultErr)
 		timedOut = false
+	case testResultErr = <- testCtx.GetAsyncFailureChan():
+		logrus.Tracef("Test was failed asynchronously before returning a result: %v", testResultErr)
+		timedOut = false
 	case <- time.After(testTimeout):
 		logrus.Tracef("Hit timeout %v before getting a result from the test", testTimeout)
 		timedOut = true
 	}
+	// An async failure may have arrived at the same time as the test returned successfully
+	if !timedOut && testResultErr == nil {
+		select {
+		case testResultErr = <- testCtx.GetAsyncFailureChan():
+		default:
+		}
+	}
 	logrus.Tracef("After running test w/timeout: resultErr: %v, timedOut: %v", testResultErr, timedOut)
 
+	if healthMonitorConfig != nil {
+		stopHealthMonitor(networkCtx)
+	}
+
 	if timedOut {
+		handleTestFailure(ctx, networkCtx, testName, testConfig, debugHoldDuration)
 		return stacktrace.NewError("Timed out after %v waiting for test to complete", testTimeout)
 	}
 	logrus.Infof("Executed test '%v'", testName)
 
 	if testResultErr != nil {
+		handleTestFailure(ctx, networkCtx, testName, testConfig, debugHoldDuration)
 		return stacktrace.Propagate(testResultErr, "An error occurred when running the test")
 	}
 
 	return nil
 }
 
+/*
+Captures diagnostics about every service in the network to a per-test directory on the suite execution volume, so
+	that the evidence survives the network teardown that happens after a failed test
+Errors are logged rather than returned, since we don't want them to mask the test failure that triggered the capture
+ */
+func captureDiagnostics(networkCtx *networks.NetworkContext, testName string, testConfig testsuite.TestConfiguration) {
+	diagnosticsDirpath := path.Join(
+		test_suite_container_mountpoints.SuiteExVolMountpoint,
+		diagnosticsDirname,
+		networks.GetDiagnosticsDirname(testName))
+	logrus.Infof("Capturing diagnostics about the test network to '%v'...", diagnosticsDirpath)
+	manifest, err := networkCtx.CaptureDiagnostics(diagnosticsDirpath, testConfig.DiagnosticsContainerPaths)
+	if err != nil {
+		logrus.Errorf("An error occurred capturing diagnostics about the test network:")
+		fmt.Fprintln(logrus.StandardLogger().Out, err)
+		return
+	}
+	for serviceId, serviceDiagnostics := range manifest.Services {
+		for _, captureErrStr := range serviceDiagnostics.Errors {
+			logrus.Warnf("Diagnostics for service '%v' are incomplete: %v", serviceId, captureErrStr)
+		}
+	}
+	logrus.Infof("Captured diagnostics about %v services", len(manifest.Services))
+}
+
+/*
+Stops the service health monitor, logging all the changes in service health that it observed
+ */
+func stopHealthMonitor(networkCtx *networks.NetworkContext) {
+	monitor := networkCtx.GetHealthMonitor()
+	networkCtx.StopHealthMonitor()
+	if monitor == nil {
+		return
+	}
+	transitions := monitor.GetStateTransitions()
+	logrus.Infof("The service health monitor observed %v service health changes:", len(transitions))
+	for _, transition := range transitions {
+		logLine := fmt.Sprintf(
+			"    %v: '%v' went from %v to %v",
+			transition.Timestamp.Format(time.RFC3339Nano),
+			transition.ServiceId,
+			transition.FromState,
+			transition.ToState)
+		if transition.Reason != "" {
+			logLine = logLine + " (" + transition.Reason + ")"
+		}
+		logrus.Info(logLine)
+	}
+}
+
+/*
+Gets how long to keep a failed test's network alive for investigation, which is only nonzero if the configurator
+	implements DebugModeTestSuiteConfigurator and enables debug mode
+ */
+func getDebugHoldDuration(configurator TestSuiteConfigurator, paramsJsonStr string) (time.Duration, error) {
+	debugModeConfigurator, ok := configurator.(DebugModeTestSuiteConfigurator)
+	if !ok {
+		return 0, nil
+	}
+	debugHoldDuration, err := debugModeConfigurator.ParseDebugHoldDuration(paramsJsonStr)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "An error occurred parsing the debug hold duration from the suite params JSON")
+	}
+	if debugHoldDuration < 0 {
+		return 0, stacktrace.NewError("The debug hold duration must not be negative, but was %v", debugHoldDuration)
+	}
+	return debugHoldDuration, nil
+}
+
+/*
+Gets how long the API container should give the test before tearing its network down. Besides the test's own timeouts,
+	this leaves time for capturing diagnostics about a failed test and then, in debug mode, for the whole hold, so the
+	network isn't torn down while it's being investigated.
+ */
+func getHardTestTimeout(test testsuite.Test, debugHoldDuration time.Duration) time.Duration {
+	return test.GetExecutionTimeout() + test.GetSetupTeardownBuffer() + diagnosticsCaptureBudget + debugHoldDuration
+}
+
+/*
+Does everything that should happen when a test fails before the test network gets torn down
+ */
+func handleTestFailure(
+		ctx context.Context,
+		networkCtx *networks.NetworkContext,
+		testName string,
+		testConfig testsuite.TestConfiguration,
+		debugHoldDuration time.Duration) {
+	captureDiagnostics(networkCtx, testName, testConfig)
+	if debugHoldDuration > 0 {
+		signalChan := make(chan os.Signal, 1)
+		signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
+		defer signal.Stop(signalChan)
+		holdNetworkForDebugging(ctx, networkCtx, debugHoldDuration, signalChan)
+	}
+}
+
+/*
+Prints how to reach each service in the network and then blocks until the hold duration elapses, a signal is received
+	on the given channel, or the context is cancelled, so that the failed network can be investigated before it's torn
+	down
+
+Returns:
+	Why the network was released
+ */
+func holdNetworkForDebugging(
+		ctx context.Context,
+		networkCtx *networks.NetworkContext,
+		holdDuration time.Duration,
+		signalChan <-chan os.Signal) debugHoldReleaseReason {
+	logrus.Infof("Debug mode is enabled; the test network will be kept alive for up to %v for investigation", holdDuration)
+	logrus.Info("Services in the test network (reachable from any container on the test network, and from the Docker host on Linux):")
+	for serviceId := range networkCtx.GetServiceIDs() {
+		service, err := networkCtx.GetService(serviceId)
+		if err != nil {
+			logrus.Warnf("Could not get service '%v' to print its info: %v", serviceId, err)
+			continue
+		}
+		usedPorts, err := networkCtx.GetServiceUsedPorts(serviceId)
+		if err != nil {
+			logrus.Warnf("Could not get the ports of service '%v' to print its info: %v", serviceId, err)
+			continue
+		}
+		portStrs := []string{}
+		for _, port := range usedPorts {
+			if port.Name == "" {
+				portStrs = append(portStrs, port.String())
+			} else {
+				portStrs = append(portStrs, fmt.Sprintf("%v (%v)", port, port.Name))
+			}
+		}
+		sort.Strings(portStrs)
+		logrus.Infof("    %v: IP %v, ports [%v]", serviceId, service.GetIPAddress(), strings.Join(portStrs, ", "))
+	}
+	logrus.Info("Service containers can also be entered with 'docker exec -it <container ID> sh', where the container ID can be found with 'docker ps'")
+	logrus.Info("Send an interrupt signal (e.g. Ctrl-C) to the testsuite to release the network early")
+
+	select {
+	case receivedSignal := <- signalChan:
+		logrus.Infof("Received signal '%v'; releasing the test network", receivedSignal)
+		return releasedBySignal
+	case <- time.After(holdDuration):
+		logrus.Infof("Debug hold duration of %v elapsed; releasing the test network", holdDuration)
+		return releasedByHoldDurationElapsing
+	case <- ctx.Done():
+		logrus.Info("Context was cancelled; releasing the test network")
+		return releasedByContextCancellation
+	}
+}
+
 // Little helper function meant to be run inside a goroutine that runs the test
-func runTestInGoroutine(test testsuite.Test, untypedNetwork interface{}) (resultErr error) {
+func runTestInGoroutine(test testsuite.Test, untypedNetwork interface{}, testCtx testsuite.TestContext) (resultErr error) {
 	// See https://medium.com/@hussachai/error-handling-in-go-a-quick-opinionated-guide-9199dd7c7f76 for details
 	defer func() {
 		if recoverResult := recover(); recoverResult != nil {
@@ -210,7 +430,7 @@ func runTestInGoroutine(test testsuite.Test, untypedNetwork interface{}) (result
 			resultErr = recoverResult.(error)
 		}
 	}()
-	test.Run(untypedNetwork, testsuite.TestContext{})
+	test.Run(untypedNetwork, testCtx)
 	logrus.Tracef("Test completed successfully")
 	return
 }
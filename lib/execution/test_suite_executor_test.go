@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package execution
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-go/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/kurtosis-tech/kurtosis-go/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+const (
+	// Long enough that the hold can only end early because of a signal or context cancellation
+	testLongHoldDuration = time.Hour
+
+	testShortHoldDuration = 10 * time.Millisecond
+
+	// How long to wait for the hold to end before failing the test, so a broken hold can't hang the test run
+	testHoldReleaseTimeout = 10 * time.Second
+)
+
+// Implements only the required TestSuiteConfigurator methods
+type basicTestConfigurator struct{}
+
+func (configurator basicTestConfigurator) SetLogLevel(logLevelStr string) error {
+	return nil
+}
+
+func (configurator basicTestConfigurator) ParseParamsAndCreateSuite(paramsJsonStr string) (testsuite.TestSuite, error) {
+	return nil, nil
+}
+
+// Also implements DebugModeTestSuiteConfigurator, returning the hold duration it was created with
+type debugModeTestConfigurator struct {
+	basicTestConfigurator
+	holdDuration time.Duration
+}
+
+func (configurator debugModeTestConfigurator) ParseDebugHoldDuration(paramsJsonStr string) (time.Duration, error) {
+	return configurator.holdDuration, nil
+}
+
+type timeoutsTestTest struct {
+	testsuite.Test
+}
+
+func (test timeoutsTestTest) GetExecutionTimeout() time.Duration {
+	return time.Minute
+}
+
+func (test timeoutsTestTest) GetSetupTeardownBuffer() time.Duration {
+	return 30 * time.Second
+}
+
+func TestGetDebugHoldDuration(t *testing.T) {
+	// Configurators that don't implement the optional interface don't enable debug mode
+	holdDuration, err := getDebugHoldDuration(basicTestConfigurator{}, "{}")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the hold duration of a basic configurator"))
+	}
+	assert.Equal(t, time.Duration(0), holdDuration)
+
+	holdDuration, err = getDebugHoldDuration(debugModeTestConfigurator{holdDuration: 10 * time.Minute}, "{}")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the hold duration of a debug mode configurator"))
+	}
+	assert.Equal(t, 10 * time.Minute, holdDuration)
+
+	_, err = getDebugHoldDuration(debugModeTestConfigurator{holdDuration: -time.Minute}, "{}")
+	assert.Error(t, err)
+}
+
+func TestGetHardTestTimeoutLeavesTimeForDiagnosticsAndHold(t *testing.T) {
+	test := timeoutsTestTest{}
+	assert.Equal(t, 90 * time.Second + diagnosticsCaptureBudget, getHardTestTimeout(test, 0))
+	assert.Equal(t, 90 * time.Second + diagnosticsCaptureBudget + 10 * time.Minute, getHardTestTimeout(test, 10 * time.Minute))
+}
+
+func TestHoldNetworkForDebuggingReleasedBySignal(t *testing.T) {
+	signalChan := make(chan os.Signal, 1)
+	signalChan <- syscall.SIGTERM
+	reason := holdNetworkForDebuggingWithTimeout(t, context.Background(), testLongHoldDuration, signalChan)
+	assert.Equal(t, releasedBySignal, reason)
+}
+
+func TestHoldNetworkForDebuggingReleasedByHoldDurationElapsing(t *testing.T) {
+	reason := holdNetworkForDebuggingWithTimeout(t, context.Background(), testShortHoldDuration, make(chan os.Signal))
+	assert.Equal(t, releasedByHoldDurationElapsing, reason)
+}
+
+func TestHoldNetworkForDebuggingReleasedByContextCancellation(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	cancelFunc()
+	reason := holdNetworkForDebuggingWithTimeout(t, ctx, testLongHoldDuration, make(chan os.Signal))
+	assert.Equal(t, releasedByContextCancellation, reason)
+}
+
+// Runs holdNetworkForDebugging on an empty network, failing the test if the hold isn't released in time
+func holdNetworkForDebuggingWithTimeout(
+		t *testing.T,
+		ctx context.Context,
+		holdDuration time.Duration,
+		signalChan <-chan os.Signal) debugHoldReleaseReason {
+	networkCtx := networks.NewNetworkContext(nil, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	reasonChan := make(chan debugHoldReleaseReason, 1)
+	go func() {
+		reasonChan <- holdNetworkForDebugging(ctx, networkCtx, holdDuration, signalChan)
+	}()
+	select {
+	case reason := <- reasonChan:
+		return reason
+	case <- time.After(testHoldReleaseTimeout):
+		t.Fatalf("The network hold wasn't released within %v", testHoldReleaseTimeout)
+		return ""
+	}
+}
@@ -0,0 +1,111 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"time"
+)
+
+/*
+Customizes how NetworkContext.AddService adds a service, e.g.:
+
+	networkCtx.AddService(serviceId, initializer, WithPartition(partitionId), WithLabels(map[string]string{"role": "validator"}))
+ */
+type AddServiceOption func(config *addServiceConfig)
+
+type addServiceConfig struct {
+	partitionId PartitionID
+
+	envVars map[string]string
+
+	labels map[string]string
+
+	// Nil if AddService shouldn't wait for the service to become available
+	startupWait *startupWaitPolicy
+
+	// Nil if the initializer's resource limits should be used
+	resourceLimits *services.ResourceLimits
+}
+
+type startupWaitPolicy struct {
+	timeout time.Duration
+	backoff services.BackoffStrategy
+}
+
+func newAddServiceConfig(options []AddServiceOption) *addServiceConfig {
+	config := &addServiceConfig{
+		partitionId:    defaultPartitionId,
+		envVars:        map[string]string{},
+		labels:         map[string]string{},
+		startupWait:    nil,
+		resourceLimits: nil,
+	}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+/*
+Adds the service to the given partition rather than the default partition, which is necessary if the network has been
+	repartitioned and the default partition hasn't been preserved
+ */
+func WithPartition(partitionId PartitionID) AddServiceOption {
+	return func(config *addServiceConfig) {
+		config.partitionId = partitionId
+	}
+}
+
+/*
+Sets environment variables in the service's container; calling this multiple times merges the variables, with later
+	values winning. Values can refer to other services with "${service:<service ID>:ip}" and
+	"${service:<service ID>:port:<port name>}".
+ */
+func WithEnvVars(envVars map[string]string) AddServiceOption {
+	return func(config *addServiceConfig) {
+		for key, value := range envVars {
+			config.envVars[key] = value
+		}
+	}
+}
+
+/*
+Attaches key/value labels (e.g. role=validator) to the service, which are retrievable via
+	NetworkContext.GetServiceLabels; calling this multiple times merges the labels, with later values winning
+ */
+func WithLabels(labels map[string]string) AddServiceOption {
+	return func(config *addServiceConfig) {
+		for key, value := range labels {
+			config.labels[key] = value
+		}
+	}
+}
+
+/*
+Makes AddService block until the service is available, returning an error if it isn't available within the timeout
+
+Args:
+	timeout: The maximum amount of time to wait for the service to become available
+	backoff: The backoff strategy used between availability checks
+ */
+func WithStartupWait(timeout time.Duration, backoff services.BackoffStrategy) AddServiceOption {
+	return func(config *addServiceConfig) {
+		config.startupWait = &startupWaitPolicy{
+			timeout: timeout,
+			backoff: backoff,
+		}
+	}
+}
+
+/*
+Overrides the resource limits declared by the service's initializer
+ */
+func WithResourceLimits(resourceLimits services.ResourceLimits) AddServiceOption {
+	return func(config *addServiceConfig) {
+		config.resourceLimits = &resourceLimits
+	}
+}
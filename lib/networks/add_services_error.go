@@ -0,0 +1,37 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"sort"
+	"strings"
+)
+
+/*
+Error returned by NetworkContext.AddServices, identifying exactly which services couldn't be added and why
+ */
+type AddServicesError struct {
+	// Mapping of serviceId -> error describing why the service couldn't be added
+	Failures map[services.ServiceID]error
+}
+
+func (err AddServicesError) Error() string {
+	serviceIdStrs := []string{}
+	for serviceId := range err.Failures {
+		serviceIdStrs = append(serviceIdStrs, string(serviceId))
+	}
+	sort.Strings(serviceIdStrs)
+
+	lines := []string{
+		fmt.Sprintf("%v service(s) could not be added: %v", len(serviceIdStrs), strings.Join(serviceIdStrs, ", ")),
+	}
+	for _, serviceIdStr := range serviceIdStrs {
+		lines = append(lines, fmt.Sprintf("Service '%v': %v", serviceIdStr, err.Failures[services.ServiceID(serviceIdStr)]))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,256 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+Adds the given services to the network in the default partition, starting each one only once all the services it
+	declares in DependenciesInitializer.GetDependencies are available. Services that don't depend on each other
+	are started in parallel.
+
+NOTE: If the network has been repartitioned and the default partition hasn't been preserved, you should use
+	AddServicesInDependencyOrderToPartition instead.
+
+Args:
+	ctx: Context whose deadline or cancellation bounds the entire bring-up, including waiting for availability
+	initializers: Mapping of serviceId -> the Docker container initializer containing the logic for starting the service
+	backoff: The backoff strategy used between polls when waiting for each service to become available
+
+Return:
+	The services that were successfully added, their availability checkers, and an error if the bring-up failed. If
+		the dependencies contain a cycle or reference a service that doesn't exist, nothing is started; otherwise
+		the root cause of the error is an *AddServicesError identifying each service that couldn't be added. A service
+		that's started but doesn't become available is removed from the network again, as with AddService's
+		WithStartupWait option, so it's never among the added services.
+ */
+func (networkCtx *NetworkContext) AddServicesInDependencyOrder(
+		ctx context.Context,
+		initializers map[services.ServiceID]services.DockerContainerInitializer,
+		backoff services.BackoffStrategy) (
+			map[services.ServiceID]services.Service,
+			map[services.ServiceID]services.AvailabilityChecker,
+			error) {
+	addedServices, availabilityCheckers, err := networkCtx.AddServicesInDependencyOrderToPartition(ctx, defaultPartitionId, initializers, backoff)
+	if err != nil {
+		return addedServices, availabilityCheckers, stacktrace.Propagate(err, "An error occurred adding services in dependency order to the default partition")
+	}
+	return addedServices, availabilityCheckers, nil
+}
+
+/*
+Same as AddServicesInDependencyOrder, but adds the services to the given partition.
+
+Every service started by this method is waited on until it's available, so when this method returns without error
+	all the services are ready to use. If a service fails to start or become available, the services that depend on
+	it (directly or transitively) aren't started.
+ */
+func (networkCtx *NetworkContext) AddServicesInDependencyOrderToPartition(
+		ctx context.Context,
+		partitionId PartitionID,
+		initializers map[services.ServiceID]services.DockerContainerInitializer,
+		backoff services.BackoffStrategy) (
+			map[services.ServiceID]services.Service,
+			map[services.ServiceID]services.AvailabilityChecker,
+			error) {
+	dependencies := map[services.ServiceID]map[services.ServiceID]bool{}
+	for serviceId, initializer := range initializers {
+		dependencies[serviceId] = services.GetInitializerDependencies(initializer)
+	}
+
+	// Dependencies outside the batch must already be in the network, and we assume they're already available
+	existingServiceIds := networkCtx.GetServiceIDs()
+	for serviceId, serviceDependencies := range dependencies {
+		for dependencyId := range serviceDependencies {
+			_, inBatch := initializers[dependencyId]
+			_, inNetwork := existingServiceIds[dependencyId]
+			if !inBatch && !inNetwork {
+				return nil, nil, stacktrace.NewError(
+					"Service '%v' depends on service '%v', which is neither being added nor already in the network",
+					serviceId,
+					dependencyId)
+			}
+		}
+	}
+	if cycle := findDependencyCycle(dependencies); cycle != nil {
+		cycleStrs := []string{}
+		for _, serviceId := range cycle {
+			cycleStrs = append(cycleStrs, string(serviceId))
+		}
+		return nil, nil, stacktrace.NewError("The service dependencies contain a cycle: %v", strings.Join(cycleStrs, " -> "))
+	}
+
+	// Each service gets a channel that's closed once it's either available or has failed, which its dependents wait on
+	doneChans := map[services.ServiceID]chan struct{}{}
+	for serviceId := range initializers {
+		doneChans[serviceId] = make(chan struct{})
+	}
+
+	resultsMutex := &sync.Mutex{}
+	addedServices := map[services.ServiceID]services.Service{}
+	availabilityCheckers := map[services.ServiceID]services.AvailabilityChecker{}
+	failures := map[services.ServiceID]error{}
+
+	waitGroup := sync.WaitGroup{}
+	for serviceId, initializer := range initializers {
+		waitGroup.Add(1)
+		go func(serviceId services.ServiceID, initializer services.DockerContainerInitializer) {
+			defer waitGroup.Done()
+			defer close(doneChans[serviceId])
+
+			hasFailed := func(dependencyId services.ServiceID) bool {
+				resultsMutex.Lock()
+				defer resultsMutex.Unlock()
+				_, found := failures[dependencyId]
+				return found
+			}
+			service, availabilityChecker, err := networkCtx.startServiceAfterDependencies(
+				ctx,
+				serviceId,
+				partitionId,
+				initializer,
+				backoff,
+				doneChans,
+				hasFailed)
+
+			resultsMutex.Lock()
+			defer resultsMutex.Unlock()
+			if err != nil {
+				failures[serviceId] = err
+				return
+			}
+			addedServices[serviceId] = service
+			availabilityCheckers[serviceId] = availabilityChecker
+		}(serviceId, initializer)
+	}
+	waitGroup.Wait()
+
+	if len(failures) > 0 {
+		return addedServices, availabilityCheckers, &AddServicesError{Failures: failures}
+	}
+	return addedServices, availabilityCheckers, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (networkCtx *NetworkContext) startServiceAfterDependencies(
+		ctx context.Context,
+		serviceId services.ServiceID,
+		partitionId PartitionID,
+		initializer services.DockerContainerInitializer,
+		backoff services.BackoffStrategy,
+		doneChans map[services.ServiceID]chan struct{},
+		hasFailed func(serviceId services.ServiceID) bool) (services.Service, services.AvailabilityChecker, error) {
+	for dependencyId := range services.GetInitializerDependencies(initializer) {
+		dependencyDoneChan, inBatch := doneChans[dependencyId]
+		if !inBatch {
+			continue
+		}
+		select {
+		case <- dependencyDoneChan:
+		case <- ctx.Done():
+			return nil, nil, stacktrace.Propagate(ctx.Err(), "The context was done while waiting for dependency '%v'", dependencyId)
+		}
+		if hasFailed(dependencyId) {
+			return nil, nil, stacktrace.NewError("Service wasn't started because its dependency '%v' failed", dependencyId)
+		}
+	}
+
+	logrus.Debugf("Dependencies of service '%v' are available; starting it...", serviceId)
+	service, availabilityChecker, err := networkCtx.AddServiceToPartition(serviceId, partitionId, initializer)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred adding the service")
+	}
+	if err := availabilityChecker.WaitForStartupWithContext(ctx, backoff); err != nil {
+		if removeErr := networkCtx.RemoveService(serviceId, 0); removeErr != nil {
+			return nil, nil, stacktrace.Propagate(
+				err,
+				"An error occurred waiting for the service to become available, and removing it also failed so the " +
+					"service may need to be removed manually; the removal error was:\n%v",
+				removeErr)
+		}
+		return nil, nil, stacktrace.Propagate(err, "An error occurred waiting for the service to become available, so it was removed")
+	}
+	logrus.Debugf("Service '%v' is available", serviceId)
+	return service, availabilityChecker, nil
+}
+
+/*
+Searches the given dependency graph for a cycle, checking services in sorted order so the result is deterministic
+
+Args:
+	dependencies: Mapping of serviceId -> "set" of the IDs of the services it depends on
+
+Returns:
+	The services forming a cycle, with the first service repeated at the end (e.g. [a, b, a]), or nil if there's no cycle
+ */
+func findDependencyCycle(dependencies map[services.ServiceID]map[services.ServiceID]bool) []services.ServiceID {
+	const (
+		unvisited = iota
+		inProgress
+		visited
+	)
+	visitStates := map[services.ServiceID]int{}
+	path := []services.ServiceID{}
+
+	var visit func(serviceId services.ServiceID) []services.ServiceID
+	visit = func(serviceId services.ServiceID) []services.ServiceID {
+		visitStates[serviceId] = inProgress
+		path = append(path, serviceId)
+		for _, dependencyId := range getSortedServiceIds(dependencies[serviceId]) {
+			switch visitStates[dependencyId] {
+			case inProgress:
+				for i, pathServiceId := range path {
+					if pathServiceId == dependencyId {
+						cycle := append([]services.ServiceID{}, path[i:]...)
+						return append(cycle, dependencyId)
+					}
+				}
+			case unvisited:
+				if cycle := visit(dependencyId); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		path = path[:len(path) - 1]
+		visitStates[serviceId] = visited
+		return nil
+	}
+
+	serviceIdSet := map[services.ServiceID]bool{}
+	for serviceId := range dependencies {
+		serviceIdSet[serviceId] = true
+	}
+	for _, serviceId := range getSortedServiceIds(serviceIdSet) {
+		if visitStates[serviceId] != unvisited {
+			continue
+		}
+		if cycle := visit(serviceId); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+func getSortedServiceIds(serviceIds map[services.ServiceID]bool) []services.ServiceID {
+	result := []services.ServiceID{}
+	for serviceId := range serviceIds {
+		result = append(result, serviceId)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-go/lib/core_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	databaseServiceId services.ServiceID = "database"
+	apiServiceId services.ServiceID = "api"
+	workerServiceId services.ServiceID = "worker"
+	cacheServiceId services.ServiceID = "cache"
+
+	testAvailabilityPollDelay = 10 * time.Millisecond
+)
+
+// Records when each service's start began & finished, so tests can verify the order services were started in
+type startRecordingTestClient struct {
+	startServicesTestClient
+
+	mutex *sync.Mutex
+	startTimes map[services.ServiceID]time.Time
+	finishTimes map[services.ServiceID]time.Time
+}
+
+func newStartRecordingTestClient() *startRecordingTestClient {
+	return &startRecordingTestClient{
+		mutex:       &sync.Mutex{},
+		startTimes:  map[services.ServiceID]time.Time{},
+		finishTimes: map[services.ServiceID]time.Time{},
+	}
+}
+
+func (client *startRecordingTestClient) StartService(ctx context.Context, in *bindings.StartServiceArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	serviceId := services.ServiceID(in.ServiceId)
+	client.mutex.Lock()
+	client.startTimes[serviceId] = time.Now()
+	client.mutex.Unlock()
+
+	result, err := client.startServicesTestClient.StartService(ctx, in, opts...)
+
+	client.mutex.Lock()
+	client.finishTimes[serviceId] = time.Now()
+	client.mutex.Unlock()
+	return result, err
+}
+
+func newDependentTestInitializer(dependencies ...services.ServiceID) *services.MockDockerContainerInitializer {
+	initializer := services.NewMockDockerContainerInitializer()
+	for _, dependencyId := range dependencies {
+		initializer.Dependencies[dependencyId] = true
+	}
+	return initializer
+}
+
+func TestAddServicesInDependencyOrder(t *testing.T) {
+	client := newStartRecordingTestClient()
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	initializers := map[services.ServiceID]services.DockerContainerInitializer{
+		databaseServiceId: newDependentTestInitializer(),
+		apiServiceId:      newDependentTestInitializer(databaseServiceId),
+		workerServiceId:   newDependentTestInitializer(apiServiceId, databaseServiceId),
+		cacheServiceId:    newDependentTestInitializer(),
+	}
+
+	addedServices, _, err := networkCtx.AddServicesInDependencyOrder(
+		context.Background(),
+		initializers,
+		services.NewConstantBackoff(testAvailabilityPollDelay))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the services in dependency order"))
+	}
+	assert.Equal(t, len(initializers), len(addedServices))
+
+	assert.False(t, client.startTimes[apiServiceId].Before(client.finishTimes[databaseServiceId]))
+	assert.False(t, client.startTimes[workerServiceId].Before(client.finishTimes[apiServiceId]))
+
+	// Independent services shouldn't wait on each other
+	assert.True(t, client.startTimes[cacheServiceId].Before(client.finishTimes[databaseServiceId]))
+}
+
+func TestAddServicesInDependencyOrderSkipsDependentsOfFailedServices(t *testing.T) {
+	client := newStartRecordingTestClient()
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	initializers := map[services.ServiceID]services.DockerContainerInitializer{
+		failingStartServiceId: newDependentTestInitializer(),
+		apiServiceId:          newDependentTestInitializer(failingStartServiceId),
+		cacheServiceId:        newDependentTestInitializer(),
+	}
+
+	addedServices, _, err := networkCtx.AddServicesInDependencyOrder(
+		context.Background(),
+		initializers,
+		services.NewConstantBackoff(testAvailabilityPollDelay))
+	addErr, ok := stacktrace.RootCause(err).(*AddServicesError)
+	if !ok {
+		t.Fatal(stacktrace.NewError("Expected an AddServicesError but got: %v", err))
+	}
+	assert.Equal(t, 2, len(addErr.Failures))
+	_, found := addErr.Failures[apiServiceId]
+	assert.True(t, found)
+
+	_, found = client.startTimes[apiServiceId]
+	assert.False(t, found)
+	_, found = addedServices[cacheServiceId]
+	assert.True(t, found)
+}
+
+func TestAddServicesInDependencyOrderRemovesUnavailableServices(t *testing.T) {
+	client := newStartRecordingTestClient()
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	unavailableInitializer := newDependentTestInitializer()
+	unavailableInitializer.AvailabilityCheck = neverAvailableCheck{}
+	initializers := map[services.ServiceID]services.DockerContainerInitializer{
+		databaseServiceId: unavailableInitializer,
+		apiServiceId:      newDependentTestInitializer(databaseServiceId),
+		cacheServiceId:    newDependentTestInitializer(),
+	}
+
+	// Long enough for the independent service to start & become available
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 500 * time.Millisecond)
+	defer cancelFunc()
+	addedServices, availabilityCheckers, err := networkCtx.AddServicesInDependencyOrder(
+		ctx,
+		initializers,
+		services.NewConstantBackoff(testAvailabilityPollDelay))
+	addErr, ok := stacktrace.RootCause(err).(*AddServicesError)
+	if !ok {
+		t.Fatal(stacktrace.NewError("Expected an AddServicesError but got: %v", err))
+	}
+	assert.Equal(t, 2, len(addErr.Failures))
+
+	_, found := addedServices[databaseServiceId]
+	assert.False(t, found)
+	_, found = availabilityCheckers[databaseServiceId]
+	assert.False(t, found)
+	_, found = networkCtx.GetServiceIDs()[databaseServiceId]
+	assert.False(t, found)
+	_, found = client.startTimes[apiServiceId]
+	assert.False(t, found)
+	_, found = addedServices[cacheServiceId]
+	assert.True(t, found)
+}
+
+func TestAddServicesInDependencyOrderRejectsInvalidDependencies(t *testing.T) {
+	client := newStartRecordingTestClient()
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	cyclicInitializers := map[services.ServiceID]services.DockerContainerInitializer{
+		databaseServiceId: newDependentTestInitializer(workerServiceId),
+		apiServiceId:      newDependentTestInitializer(databaseServiceId),
+		workerServiceId:   newDependentTestInitializer(apiServiceId),
+	}
+	_, _, err := networkCtx.AddServicesInDependencyOrder(context.Background(), cyclicInitializers, services.NewConstantBackoff(testAvailabilityPollDelay))
+	assert.Error(t, err)
+
+	missingDependencyInitializers := map[services.ServiceID]services.DockerContainerInitializer{
+		apiServiceId: newDependentTestInitializer(databaseServiceId),
+	}
+	_, _, err = networkCtx.AddServicesInDependencyOrder(context.Background(), missingDependencyInitializers, services.NewConstantBackoff(testAvailabilityPollDelay))
+	assert.Error(t, err)
+
+	assert.Equal(t, 0, len(client.startTimes))
+}
+
+func TestFindDependencyCycle(t *testing.T) {
+	acyclicDependencies := map[services.ServiceID]map[services.ServiceID]bool{
+		databaseServiceId: {},
+		apiServiceId:      {databaseServiceId: true},
+		workerServiceId:   {apiServiceId: true, databaseServiceId: true},
+	}
+	assert.Nil(t, findDependencyCycle(acyclicDependencies))
+
+	cyclicDependencies := map[services.ServiceID]map[services.ServiceID]bool{
+		cacheServiceId:    {},
+		apiServiceId:      {databaseServiceId: true},
+		databaseServiceId: {workerServiceId: true},
+		workerServiceId:   {apiServiceId: true},
+	}
+	expectedCycle := []services.ServiceID{apiServiceId, databaseServiceId, workerServiceId, apiServiceId}
+	assert.Equal(t, expectedCycle, findDependencyCycle(cyclicDependencies))
+
+	selfDependencies := map[services.ServiceID]map[services.ServiceID]bool{
+		cacheServiceId: {cacheServiceId: true},
+	}
+	assert.Equal(t, []services.ServiceID{cacheServiceId, cacheServiceId}, findDependencyCycle(selfDependencies))
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/kurtosis-tech/kurtosis-go/lib/core_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	DiagnosticsManifestFilename = "manifest.json"
+
+	networkStateFilename = "network_state.json"
+
+	serviceLogsFilename = "logs.txt"
+	serviceInspectFilename = "inspect.json"
+	capturedPathsDirname = "files"
+
+	diagnosticsFilePerms = 0644
+
+	// Number of hex characters of a name's hash to append to a diagnostics directory name when the name had to be
+	//  sanitized, so that e.g. "a/b" and "a_b" don't share a directory
+	sanitizedNameHashLength = 8
+)
+
+// Matches the characters that aren't allowed in the name of a diagnostics directory
+var disallowedDiagnosticsDirnameCharsRegex = regexp.MustCompile("[^a-zA-Z0-9_-]")
+
+/*
+Record of the diagnostics captured for a single service
+NOTE: All filepaths are relative to the diagnostics directory that the manifest lives in
+ */
+type ServiceDiagnostics struct {
+	// Empty if the logs couldn't be captured
+	LogsFilepath string `json:"logsFilepath"`
+
+	// Empty if the container info couldn't be captured
+	InspectFilepath string `json:"inspectFilepath"`
+
+	// Mapping of path_in_container -> captured_filepath, for each in-container path that was captured successfully
+	CapturedPaths map[string]string `json:"capturedPaths"`
+
+	// Errors that occurred during capture; these are recorded rather than aborting the capture, so that a single
+	//  failure doesn't lose the rest of the evidence
+	Errors []string `json:"errors"`
+}
+
+/*
+Describes everything that was captured by NetworkContext.CaptureDiagnostics, and is written as JSON alongside the
+	captured diagnostics
+ */
+type DiagnosticsManifest struct {
+	CaptureTime time.Time `json:"captureTime"`
+
+	// Empty if the network state couldn't be captured
+	NetworkStateFilepath string `json:"networkStateFilepath"`
+
+	Services map[services.ServiceID]*ServiceDiagnostics `json:"services"`
+}
+
+/*
+Captures the logs, container info, and the given in-container paths of every service in the network into the given
+	directory, writing a DiagnosticsManifest describing what was captured. This is intended for preserving evidence
+	when a test fails, before the network is torn down.
+
+Args:
+	destDirpath: The directory *on the testsuite container* where diagnostics should be written; this should be
+		underneath the suite execution volume mountpoint so that it persists after the testsuite exits
+	containerPaths: Paths inside each service's container that should be captured, in addition to the logs & container
+		info. Paths that don't exist in a given service's container are recorded as errors in the manifest.
+
+Return:
+	The manifest of what was captured
+ */
+func (networkCtx *NetworkContext) CaptureDiagnostics(destDirpath string, containerPaths []string) (*DiagnosticsManifest, error) {
+	if err := os.MkdirAll(destDirpath, os.ModePerm); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating diagnostics directory '%v'", destDirpath)
+	}
+
+	// We only hold the mutex while getting the service IDs, since capturing can take a while
+	networkCtx.mutex.Lock()
+	serviceIds := []services.ServiceID{}
+	for serviceId := range networkCtx.services {
+		serviceIds = append(serviceIds, serviceId)
+	}
+	networkCtx.mutex.Unlock()
+
+	manifest := &DiagnosticsManifest{
+		CaptureTime: time.Now(),
+		Services:    map[services.ServiceID]*ServiceDiagnostics{},
+	}
+
+	networkStateBytes, err := networkCtx.DumpNetworkStateJson()
+	if err != nil {
+		logrus.Errorf("An error occurred dumping the network state for diagnostics: %v", err)
+	} else if err := ioutil.WriteFile(path.Join(destDirpath, networkStateFilename), networkStateBytes, diagnosticsFilePerms); err != nil {
+		logrus.Errorf("An error occurred writing the network state for diagnostics: %v", err)
+	} else {
+		manifest.NetworkStateFilepath = networkStateFilename
+	}
+	for _, serviceId := range serviceIds {
+		logrus.Debugf("Capturing diagnostics for service '%v'...", serviceId)
+		manifest.Services[serviceId] = networkCtx.captureServiceDiagnostics(destDirpath, serviceId, containerPaths)
+		logrus.Debugf("Captured diagnostics for service '%v'", serviceId)
+	}
+
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred serializing the diagnostics manifest")
+	}
+	manifestFilepath := path.Join(destDirpath, DiagnosticsManifestFilename)
+	if err := ioutil.WriteFile(manifestFilepath, manifestBytes, diagnosticsFilePerms); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred writing the diagnostics manifest to '%v'", manifestFilepath)
+	}
+	return manifest, nil
+}
+
+/*
+Gets the name of a directory of diagnostics about the given test or service, which is the name with anything other
+	than letters, digits, '_' & '-' replaced so that a name containing e.g. '/' or '..' can't nest directories or escape
+	the diagnostics directory
+ */
+func GetDiagnosticsDirname(name string) string {
+	sanitizedName := disallowedDiagnosticsDirnameCharsRegex.ReplaceAllString(name, "_")
+	if sanitizedName == name && name != "" {
+		return name
+	}
+	nameHash := sha1.Sum([]byte(name))
+	return sanitizedName + "-" + hex.EncodeToString(nameHash[:])[:sanitizedNameHashLength]
+}
+
+func (networkCtx *NetworkContext) captureServiceDiagnostics(
+		destDirpath string,
+		serviceId services.ServiceID,
+		containerPaths []string) *ServiceDiagnostics {
+	ctx := context.Background()
+	result := &ServiceDiagnostics{
+		CapturedPaths: map[string]string{},
+		Errors:        []string{},
+	}
+
+	serviceRelativeDirpath := GetDiagnosticsDirname(string(serviceId))
+	serviceDirpath := path.Join(destDirpath, serviceRelativeDirpath)
+	if err := os.MkdirAll(serviceDirpath, os.ModePerm); err != nil {
+		result.Errors = append(result.Errors, stacktrace.Propagate(err, "An error occurred creating directory '%v'", serviceDirpath).Error())
+		return result
+	}
+
+	logsResp, err := networkCtx.client.GetServiceLogs(ctx, &bindings.GetServiceLogsArgs{ServiceId: string(serviceId)})
+	if err != nil {
+		result.Errors = append(result.Errors, stacktrace.Propagate(err, "An error occurred getting the service logs").Error())
+	} else if err := ioutil.WriteFile(path.Join(serviceDirpath, serviceLogsFilename), logsResp.Logs, diagnosticsFilePerms); err != nil {
+		result.Errors = append(result.Errors, stacktrace.Propagate(err, "An error occurred writing the service logs").Error())
+	} else {
+		result.LogsFilepath = path.Join(serviceRelativeDirpath, serviceLogsFilename)
+	}
+
+	inspectResp, err := networkCtx.client.InspectService(ctx, &bindings.InspectServiceArgs{ServiceId: string(serviceId)})
+	if err != nil {
+		result.Errors = append(result.Errors, stacktrace.Propagate(err, "An error occurred inspecting the service container").Error())
+	} else if err := ioutil.WriteFile(path.Join(serviceDirpath, serviceInspectFilename), inspectResp.InspectJson, diagnosticsFilePerms); err != nil {
+		result.Errors = append(result.Errors, stacktrace.Propagate(err, "An error occurred writing the service container info").Error())
+	} else {
+		result.InspectFilepath = path.Join(serviceRelativeDirpath, serviceInspectFilename)
+	}
+
+	for _, containerPath := range containerPaths {
+		// Mirror the in-container directory structure, so that e.g. /var/log ends up at files/var/log
+		capturedRelativeFilepath := path.Join(serviceRelativeDirpath, capturedPathsDirname, strings.TrimPrefix(path.Clean(containerPath), "/"))
+		if err := networkCtx.captureContainerPath(serviceId, containerPath, path.Join(destDirpath, capturedRelativeFilepath)); err != nil {
+			result.Errors = append(result.Errors, stacktrace.Propagate(err, "An error occurred capturing path '%v'", containerPath).Error())
+			continue
+		}
+		result.CapturedPaths[containerPath] = capturedRelativeFilepath
+	}
+
+	return result
+}
+
+func (networkCtx *NetworkContext) captureContainerPath(serviceId services.ServiceID, containerPath string, destPath string) error {
+	copiedPath, err := networkCtx.copyFromContainer(serviceId, containerPath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred copying the path out of the container")
+	}
+	if err := os.MkdirAll(path.Dir(destPath), os.ModePerm); err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating the parent directory of '%v'", destPath)
+	}
+	if err := os.Rename(copiedPath, destPath); err != nil {
+		return stacktrace.Propagate(err, "An error occurred moving copied path '%v' to '%v'", copiedPath, destPath)
+	}
+	// The directory the copied path was extracted into is now empty, so we tidy it up
+	if err := os.Remove(path.Dir(copiedPath)); err != nil {
+		logrus.Debugf("An error occurred removing empty extraction directory '%v': %v", path.Dir(copiedPath), err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/kurtosis-tech/kurtosis-go/lib/core_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+const (
+	healthyServiceId services.ServiceID = "healthy-service"
+	nestedServiceId services.ServiceID = "../nested/service"
+	uninspectableServiceId services.ServiceID = "uninspectable-service"
+
+	testLogs = "some logs"
+	testInspectJson = "{\"Id\": \"abc123\"}"
+)
+
+// Only the endpoints used by diagnostics capture are implemented; calling any other will panic
+type diagnosticsTestClient struct {
+	bindings.TestExecutionServiceClient
+}
+
+func (client diagnosticsTestClient) GetServiceLogs(ctx context.Context, in *bindings.GetServiceLogsArgs, opts ...grpc.CallOption) (*bindings.GetServiceLogsResponse, error) {
+	return &bindings.GetServiceLogsResponse{Logs: []byte(testLogs)}, nil
+}
+
+func (client diagnosticsTestClient) InspectService(ctx context.Context, in *bindings.InspectServiceArgs, opts ...grpc.CallOption) (*bindings.InspectServiceResponse, error) {
+	if in.ServiceId == string(uninspectableServiceId) {
+		return nil, stacktrace.NewError("Test inspect error")
+	}
+	return &bindings.InspectServiceResponse{InspectJson: []byte(testInspectJson)}, nil
+}
+
+func TestCaptureDiagnostics(t *testing.T) {
+	tempDirpath, err := ioutil.TempDir("", "diagnostics-test")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the temporary directory"))
+	}
+	defer os.RemoveAll(tempDirpath)
+
+	networkCtx := NewNetworkContext(diagnosticsTestClient{}, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	networkCtx.services[healthyServiceId] = services.NewMockService(healthyServiceId, "1.2.3.4", 1)
+	networkCtx.services[uninspectableServiceId] = services.NewMockService(uninspectableServiceId, "1.2.3.5", 1)
+
+	manifest, err := networkCtx.CaptureDiagnostics(tempDirpath, []string{})
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred capturing diagnostics"))
+	}
+	assert.Equal(t, 2, len(manifest.Services))
+
+	healthyDiagnostics := manifest.Services[healthyServiceId]
+	assert.Empty(t, healthyDiagnostics.Errors)
+	logs, err := ioutil.ReadFile(path.Join(tempDirpath, healthyDiagnostics.LogsFilepath))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading the captured logs"))
+	}
+	assert.Equal(t, testLogs, string(logs))
+	inspectJson, err := ioutil.ReadFile(path.Join(tempDirpath, healthyDiagnostics.InspectFilepath))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading the captured container info"))
+	}
+	assert.Equal(t, testInspectJson, string(inspectJson))
+
+	// A failure capturing one piece of evidence shouldn't prevent the rest from being captured
+	uninspectableDiagnostics := manifest.Services[uninspectableServiceId]
+	assert.Equal(t, 1, len(uninspectableDiagnostics.Errors))
+	assert.Empty(t, uninspectableDiagnostics.InspectFilepath)
+	assert.NotEmpty(t, uninspectableDiagnostics.LogsFilepath)
+
+	manifestBytes, err := ioutil.ReadFile(path.Join(tempDirpath, DiagnosticsManifestFilename))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading the manifest"))
+	}
+	var writtenManifest DiagnosticsManifest
+	if err := json.Unmarshal(manifestBytes, &writtenManifest); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred deserializing the manifest"))
+	}
+	assert.Equal(t, 2, len(writtenManifest.Services))
+	assert.NotEmpty(t, writtenManifest.NetworkStateFilepath)
+}
+
+func TestCaptureDiagnosticsKeepsServicesInsideDestDir(t *testing.T) {
+	destDirpath := path.Join(t.TempDir(), "diagnostics")
+	networkCtx := NewNetworkContext(diagnosticsTestClient{}, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	networkCtx.services[nestedServiceId] = services.NewMockService(nestedServiceId, "1.2.3.4", 1)
+
+	manifest, err := networkCtx.CaptureDiagnostics(destDirpath, []string{})
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred capturing diagnostics"))
+	}
+	nestedDiagnostics := manifest.Services[nestedServiceId]
+	assert.Empty(t, nestedDiagnostics.Errors)
+
+	// The service's directory should be directly inside the destination directory, rather than nested or escaping it
+	logsFilepath := path.Join(destDirpath, nestedDiagnostics.LogsFilepath)
+	assert.Equal(t, destDirpath, filepath.Dir(filepath.Dir(logsFilepath)))
+	assert.Equal(t, GetDiagnosticsDirname(string(nestedServiceId)), filepath.Base(filepath.Dir(logsFilepath)))
+	logs, err := ioutil.ReadFile(logsFilepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading the captured logs"))
+	}
+	assert.Equal(t, testLogs, string(logs))
+}
+
+func TestGetDiagnosticsDirname(t *testing.T) {
+	assert.Equal(t, "basicDatastoreTest", GetDiagnosticsDirname("basicDatastoreTest"))
+	assert.Equal(t, "basic-datastore_test", GetDiagnosticsDirname("basic-datastore_test"))
+
+	validDirnameRegex := regexp.MustCompile("^[a-zA-Z0-9_-]+$")
+	unsafeNames := []string{"..", ".", "", "../../etc", "nested/test", "a_b", "a/b"}
+	dirnames := map[string]bool{}
+	for _, name := range unsafeNames {
+		dirname := GetDiagnosticsDirname(name)
+		assert.Regexp(t, validDirnameRegex, dirname, "Unsafe diagnostics dirname for name '%v'", name)
+		dirnames[dirname] = true
+	}
+	// Names that sanitize to the same string still get distinct directories
+	assert.Equal(t, len(unsafeNames), len(dirnames))
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"path"
+	"sort"
+	"strings"
+)
+
+/*
+Checks the configuration of a container's initializer, so that misconfigurations are reported precisely before anything
+	is registered with the Kurtosis API rather than failing deep inside the API container
+
+Returns:
+	An error listing every problem with the initializer, or nil if there are none
+ */
+func (networkCtx *NetworkContext) validateContainerInitializer(
+		initializer containerInitializer,
+		usedPorts []services.Port,
+		generatedFiles map[string]*services.GeneratedFile) error {
+	problems := []string{}
+
+	if strings.TrimSpace(initializer.GetDockerImage()) == "" {
+		problems = append(problems, "No Docker image was declared")
+	}
+
+	if err := services.ValidatePorts(usedPorts); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	testVolumeMountpoint := initializer.GetTestVolumeMountpoint()
+	isTestVolumeMountpointValid := false
+	if testVolumeMountpoint == "" {
+		problems = append(problems, "No test volume mountpoint was declared")
+	} else if !path.IsAbs(testVolumeMountpoint) {
+		problems = append(problems, fmt.Sprintf("Test volume mountpoint '%v' isn't an absolute path", testVolumeMountpoint))
+	} else {
+		isTestVolumeMountpointValid = true
+	}
+
+	filesArtifactMountpoints := initializer.GetFilesArtifactMountpoints()
+	sortedFilesArtifactIds := []string{}
+	for filesArtifactId := range filesArtifactMountpoints {
+		sortedFilesArtifactIds = append(sortedFilesArtifactIds, string(filesArtifactId))
+	}
+	sort.Strings(sortedFilesArtifactIds)
+	for _, filesArtifactIdStr := range sortedFilesArtifactIds {
+		filesArtifactId := services.FilesArtifactID(filesArtifactIdStr)
+		mountpoint := filesArtifactMountpoints[filesArtifactId]
+		_, isUrlArtifact := networkCtx.filesArtifactUrls[filesArtifactId]
+		_, isLocalArtifact := networkCtx.localFilesArtifactPaths[filesArtifactId]
+		if !isUrlArtifact && !isLocalArtifact {
+			problems = append(problems, fmt.Sprintf(
+				"Files artifact '%v' isn't declared in the test's TestConfiguration.FilesArtifactUrls or LocalFilesArtifacts",
+				filesArtifactId))
+		}
+		if isLocalArtifact {
+			if err := ValidateLocalFilesArtifactId(filesArtifactId); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+		if !path.IsAbs(mountpoint) {
+			problems = append(problems, fmt.Sprintf("Mountpoint '%v' of files artifact '%v' isn't an absolute path", mountpoint, filesArtifactId))
+			continue
+		}
+		if isTestVolumeMountpointValid && doDirpathsOverlap(mountpoint, testVolumeMountpoint) {
+			problems = append(problems, fmt.Sprintf(
+				"Mountpoint '%v' of files artifact '%v' overlaps the test volume mountpoint '%v'",
+				mountpoint,
+				filesArtifactId,
+				testVolumeMountpoint))
+		}
+	}
+
+	sortedGeneratedFileKeys := []string{}
+	for fileKey := range generatedFiles {
+		sortedGeneratedFileKeys = append(sortedGeneratedFileKeys, fileKey)
+	}
+	sort.Strings(sortedGeneratedFileKeys)
+	for _, fileKey := range sortedGeneratedFileKeys {
+		generatedFile := generatedFiles[fileKey]
+		if generatedFile == nil {
+			problems = append(problems, fmt.Sprintf("Generated file '%v' is nil", fileKey))
+			continue
+		}
+		if err := generatedFile.Validate(); err != nil {
+			problems = append(problems, stacktrace.Propagate(err, "Generated file '%v' is invalid", fileKey).Error())
+		}
+	}
+
+	// Shared volumes are writable, so they mustn't be mounted over (or inside) anything else Kurtosis mounts
+	sharedVolumeMountpoints := services.GetInitializerSharedVolumeMountpoints(initializer)
+	sortedSharedVolumeIds := []string{}
+	for volumeId := range sharedVolumeMountpoints {
+		sortedSharedVolumeIds = append(sortedSharedVolumeIds, string(volumeId))
+	}
+	sort.Strings(sortedSharedVolumeIds)
+	otherMountpointDescriptions := map[string]string{}
+	if isTestVolumeMountpointValid {
+		otherMountpointDescriptions[testVolumeMountpoint] = "the test volume mountpoint"
+	}
+	for filesArtifactId, mountpoint := range filesArtifactMountpoints {
+		if path.IsAbs(mountpoint) {
+			otherMountpointDescriptions[mountpoint] = fmt.Sprintf("the mountpoint of files artifact '%v'", filesArtifactId)
+		}
+	}
+	for _, volumeIdStr := range sortedSharedVolumeIds {
+		volumeId := services.SharedVolumeID(volumeIdStr)
+		mountpoint := sharedVolumeMountpoints[volumeId]
+		if strings.TrimSpace(volumeIdStr) == "" {
+			problems = append(problems, "A shared volume has an empty ID")
+		}
+		if !path.IsAbs(mountpoint) {
+			problems = append(problems, fmt.Sprintf("Mountpoint '%v' of shared volume '%v' isn't an absolute path", mountpoint, volumeId))
+			continue
+		}
+		sortedOtherMountpoints := []string{}
+		for otherMountpoint := range otherMountpointDescriptions {
+			sortedOtherMountpoints = append(sortedOtherMountpoints, otherMountpoint)
+		}
+		sort.Strings(sortedOtherMountpoints)
+		for _, otherMountpoint := range sortedOtherMountpoints {
+			otherMountpointDescription := otherMountpointDescriptions[otherMountpoint]
+			if doDirpathsOverlap(mountpoint, otherMountpoint) {
+				problems = append(problems, fmt.Sprintf(
+					"Mountpoint '%v' of shared volume '%v' overlaps %v '%v'",
+					mountpoint,
+					volumeId,
+					otherMountpointDescription,
+					otherMountpoint))
+			}
+		}
+		otherMountpointDescriptions[mountpoint] = fmt.Sprintf("the mountpoint of shared volume '%v'", volumeId)
+	}
+
+	if len(problems) > 0 {
+		return stacktrace.NewError("The container initializer is invalid:\n%v", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// Returns true if the directories are the same, or one is inside the other
+func doDirpathsOverlap(dirpath1 string, dirpath2 string) bool {
+	cleanedDirpath1 := path.Clean(dirpath1)
+	cleanedDirpath2 := path.Clean(dirpath2)
+	return isDirpathWithin(cleanedDirpath1, cleanedDirpath2) || isDirpathWithin(cleanedDirpath2, cleanedDirpath1)
+}
+
+// Returns true if the first cleaned absolute dirpath is the same as, or inside of, the second
+func isDirpathWithin(dirpath string, parentDirpath string) bool {
+	if dirpath == parentDirpath || parentDirpath == "/" {
+		return true
+	}
+	return strings.HasPrefix(dirpath, parentDirpath + "/")
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const (
+	testFilesArtifactId services.FilesArtifactID = "test-artifact"
+)
+
+func TestDirpathsOverlap(t *testing.T) {
+	assert.True(t, doDirpathsOverlap("/test-volume", "/test-volume/"))
+	assert.True(t, doDirpathsOverlap("/test-volume/static", "/test-volume"))
+	assert.True(t, doDirpathsOverlap("/test-volume", "/test-volume/static"))
+	assert.True(t, doDirpathsOverlap("/", "/test-volume"))
+	assert.False(t, doDirpathsOverlap("/test-volume", "/test-volume-2"))
+	assert.False(t, doDirpathsOverlap("/static", "/test-volume"))
+}
+
+func TestAddServiceReportsEveryInitializerProblem(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{testFilesArtifactId: "https://example.com/artifact.tgz"}, map[services.FilesArtifactID]string{})
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.UsedPorts = []services.Port{
+		{Number: 80, Protocol: "tpc"},
+		{Number: 8080, Protocol: services.TCP, Name: "http"},
+		{Number: 8081, Protocol: services.TCP, Name: "http"},
+	}
+	initializer.TestVolumeMountpoint = "test-volume"
+	initializer.FilesArtifactMountpoints = map[services.FilesArtifactID]string{
+		"undeclared-artifact": "/static",
+		testFilesArtifactId:   "relative/dir",
+	}
+	initializer.SharedVolumeMountpoints = map[services.SharedVolumeID]string{
+		"keystore": "relative/keystore",
+	}
+
+	_, _, err := networkCtx.AddService(service1, initializer)
+	assert.Error(t, err)
+	errStr := err.Error()
+	assert.Contains(t, errStr, "Port 80 has unrecognized protocol 'tpc'")
+	assert.Contains(t, errStr, "Port name 'http' is used by more than one port")
+	assert.Contains(t, errStr, "Test volume mountpoint 'test-volume' isn't an absolute path")
+	assert.Contains(t, errStr, "Files artifact 'undeclared-artifact' isn't declared in the test's TestConfiguration.FilesArtifactUrls or LocalFilesArtifacts")
+	assert.Contains(t, errStr, "Mountpoint 'relative/dir' of files artifact 'test-artifact' isn't an absolute path")
+	assert.Contains(t, errStr, "Mountpoint 'relative/keystore' of shared volume 'keystore' isn't an absolute path")
+
+	// Nothing should have been registered
+	assert.Nil(t, client.lastRegisterServiceArgs)
+}
+
+func TestAddServiceRejectsArtifactOverlappingTestVolume(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{testFilesArtifactId: "https://example.com/artifact.tgz"}, map[services.FilesArtifactID]string{})
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.TestVolumeMountpoint = "/data"
+	initializer.FilesArtifactMountpoints = map[services.FilesArtifactID]string{testFilesArtifactId: "/data/static"}
+
+	_, _, err := networkCtx.AddService(service1, initializer)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Mountpoint '/data/static' of files artifact 'test-artifact' overlaps the test volume mountpoint '/data'")
+	assert.Nil(t, client.lastRegisterServiceArgs)
+}
+
+func TestAddServiceRejectsOverlappingSharedVolumes(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{testFilesArtifactId: "https://example.com/artifact.tgz"}, map[services.FilesArtifactID]string{})
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.FilesArtifactMountpoints = map[services.FilesArtifactID]string{testFilesArtifactId: "/static"}
+	initializer.SharedVolumeMountpoints = map[services.SharedVolumeID]string{
+		"genesis":  "/test-volume/genesis",
+		"keystore": "/static/keys",
+		"shared":   "/static/keys/shared",
+	}
+
+	_, _, err := networkCtx.AddService(service1, initializer)
+	assert.Error(t, err)
+	errStr := err.Error()
+	assert.Contains(t, errStr, "Mountpoint '/test-volume/genesis' of shared volume 'genesis' overlaps the test volume mountpoint '/test-volume'")
+	assert.Contains(t, errStr, "Mountpoint '/static/keys' of shared volume 'keystore' overlaps the mountpoint of files artifact 'test-artifact' '/static'")
+	assert.Contains(t, errStr, "Mountpoint '/static/keys/shared' of shared volume 'shared' overlaps the mountpoint of shared volume 'keystore' '/static/keys'")
+	assert.Nil(t, client.lastRegisterServiceArgs)
+}
@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"github.com/palantir/stacktrace"
+	"strings"
+)
+
+const (
+	labelSelectorRequirementSeparator = ","
+	labelSelectorEqualsOperator = "="
+	labelSelectorNotEqualsOperator = "!="
+	labelSelectorDoesNotExistPrefix = "!"
+
+	// Characters that belong to the selector syntax, so can't be part of a key (e.g. "!role=validator" is invalid)
+	labelKeyDisallowedChars = "!="
+)
+
+type labelOperator int
+
+const (
+	labelOperatorEquals labelOperator = iota
+	labelOperatorNotEquals
+	labelOperatorExists
+	labelOperatorDoesNotExist
+)
+
+type labelRequirement struct {
+	key string
+	operator labelOperator
+	// Unused for the exists & does-not-exist operators
+	value string
+}
+
+/*
+Selects services by the labels attached to them via the WithLabels option. A service matches the selector only if
+	its labels satisfy every one of the selector's requirements; the empty selector matches every service.
+ */
+type LabelSelector struct {
+	requirements []labelRequirement
+}
+
+/*
+Creates a selector matching services whose labels contain all the given key/value pairs, e.g. role=validator
+ */
+func NewLabelSelector(matchLabels map[string]string) *LabelSelector {
+	requirements := []labelRequirement{}
+	for key, value := range matchLabels {
+		requirements = append(requirements, labelRequirement{
+			key:      key,
+			operator: labelOperatorEquals,
+			value:    value,
+		})
+	}
+	return &LabelSelector{requirements: requirements}
+}
+
+/*
+Parses a selector from a comma-separated list of requirements, all of which must be satisfied, where each requirement
+	is one of:
+		key=value	The label must be present with the given value
+		key!=value	The label must be absent or have a different value
+		key			The label must be present, with any value
+		!key		The label must be absent
+
+	e.g. "role=validator,zone!=a,!legacy"
+ */
+func ParseLabelSelector(selectorStr string) (*LabelSelector, error) {
+	requirements := []labelRequirement{}
+	if strings.TrimSpace(selectorStr) == "" {
+		return &LabelSelector{requirements: requirements}, nil
+	}
+	for _, requirementStr := range strings.Split(selectorStr, labelSelectorRequirementSeparator) {
+		requirement, err := parseLabelRequirement(strings.TrimSpace(requirementStr))
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred parsing requirement '%v' of label selector '%v'", requirementStr, selectorStr)
+		}
+		requirements = append(requirements, requirement)
+	}
+	return &LabelSelector{requirements: requirements}, nil
+}
+
+/*
+Returns true if the given labels satisfy all of the selector's requirements
+ */
+func (selector LabelSelector) Matches(labels map[string]string) bool {
+	for _, requirement := range selector.requirements {
+		value, found := labels[requirement.key]
+		var isSatisfied bool
+		switch requirement.operator {
+		case labelOperatorEquals:
+			isSatisfied = found && value == requirement.value
+		case labelOperatorNotEquals:
+			isSatisfied = !found || value != requirement.value
+		case labelOperatorExists:
+			isSatisfied = found
+		case labelOperatorDoesNotExist:
+			isSatisfied = !found
+		}
+		if !isSatisfied {
+			return false
+		}
+	}
+	return true
+}
+
+func parseLabelRequirement(requirementStr string) (labelRequirement, error) {
+	// The not-equals operator must be checked before the equals operator, since it contains it
+	if strings.Contains(requirementStr, labelSelectorNotEqualsOperator) {
+		keyAndValue := strings.SplitN(requirementStr, labelSelectorNotEqualsOperator, 2)
+		return newKeyValueLabelRequirement(keyAndValue[0], labelOperatorNotEquals, keyAndValue[1])
+	}
+	if strings.Contains(requirementStr, labelSelectorEqualsOperator) {
+		keyAndValue := strings.SplitN(requirementStr, labelSelectorEqualsOperator, 2)
+		return newKeyValueLabelRequirement(keyAndValue[0], labelOperatorEquals, keyAndValue[1])
+	}
+
+	operator := labelOperatorExists
+	key := requirementStr
+	if strings.HasPrefix(requirementStr, labelSelectorDoesNotExistPrefix) {
+		operator = labelOperatorDoesNotExist
+		key = strings.TrimPrefix(requirementStr, labelSelectorDoesNotExistPrefix)
+	}
+	key = strings.TrimSpace(key)
+	if err := validateLabelKey(key); err != nil {
+		return labelRequirement{}, stacktrace.Propagate(err, "Label requirement has an invalid key")
+	}
+	return labelRequirement{key: key, operator: operator}, nil
+}
+
+func newKeyValueLabelRequirement(key string, operator labelOperator, value string) (labelRequirement, error) {
+	key = strings.TrimSpace(key)
+	if err := validateLabelKey(key); err != nil {
+		return labelRequirement{}, stacktrace.Propagate(err, "Label requirement has an invalid key")
+	}
+	return labelRequirement{
+		key:      key,
+		operator: operator,
+		value:    strings.TrimSpace(value),
+	}, nil
+}
+
+func validateLabelKey(key string) error {
+	if key == "" {
+		return stacktrace.NewError("Label key is empty")
+	}
+	if strings.ContainsAny(key, labelKeyDisallowedChars) {
+		return stacktrace.NewError("Label key '%v' contains one of the disallowed characters '%v'", key, labelKeyDisallowedChars)
+	}
+	return nil
+}
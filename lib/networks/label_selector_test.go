@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLabelSelectorMatches(t *testing.T) {
+	validatorLabels := map[string]string{"role": "validator", "zone": "a", "version": "v2"}
+	legacyLabels := map[string]string{"role": "validator", "zone": "b", "legacy": "true"}
+	noLabels := map[string]string{}
+
+	equalsSelector := NewLabelSelector(map[string]string{"role": "validator", "zone": "a"})
+	assert.True(t, equalsSelector.Matches(validatorLabels))
+	assert.False(t, equalsSelector.Matches(legacyLabels))
+	assert.False(t, equalsSelector.Matches(noLabels))
+
+	emptySelector := NewLabelSelector(map[string]string{})
+	assert.True(t, emptySelector.Matches(validatorLabels))
+	assert.True(t, emptySelector.Matches(noLabels))
+
+	parsedSelector, err := ParseLabelSelector("role=validator, zone!=a, !legacy")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred parsing the label selector"))
+	}
+	assert.False(t, parsedSelector.Matches(validatorLabels))
+	assert.False(t, parsedSelector.Matches(legacyLabels))
+	assert.True(t, parsedSelector.Matches(map[string]string{"role": "validator", "zone": "c"}))
+
+	existsSelector, err := ParseLabelSelector("version")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred parsing the label selector"))
+	}
+	assert.True(t, existsSelector.Matches(validatorLabels))
+	assert.False(t, existsSelector.Matches(legacyLabels))
+}
+
+func TestParseLabelSelectorErrors(t *testing.T) {
+	emptySelector, err := ParseLabelSelector("")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred parsing the empty label selector"))
+	}
+	assert.True(t, emptySelector.Matches(map[string]string{}))
+
+	for _, invalidSelectorStr := range []string{"=validator", "role=validator,", "!", "!=a"} {
+		_, err := ParseLabelSelector(invalidSelectorStr)
+		assert.Error(t, err, "Expected selector '%v' to be invalid", invalidSelectorStr)
+	}
+
+	// The does-not-exist prefix can't be combined with a value, and mustn't silently become part of the key
+	for _, invalidKeySelectorStr := range []string{"!role=validator", "!role!=validator", "!!role"} {
+		_, err := ParseLabelSelector(invalidKeySelectorStr)
+		assert.Error(t, err, "Expected selector '%v' to be invalid", invalidKeySelectorStr)
+	}
+}
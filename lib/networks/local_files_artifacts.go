@@ -0,0 +1,214 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/kurtosis-tech/kurtosis-go/lib/test_suite_docker_consts/test_suite_container_mountpoints"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// Directory, relative to the suite execution volume root, where local files artifacts are packed so that the
+	//  Kurtosis API can mount them
+	localFilesArtifactsDirname = "local-files-artifacts"
+
+	tgzArchiveExtension = ".tgz"
+	tarGzArchiveExtension = ".tar.gz"
+	tarArchiveExtension = ".tar"
+)
+
+/*
+Checks that the given ID can be declared in TestConfiguration.LocalFilesArtifacts, which requires it to be usable as
+	part of the name of the archive the artifact is packed into
+
+Returns:
+	An error describing why the ID can't be used, or nil if it can
+ */
+func ValidateLocalFilesArtifactId(filesArtifactId services.FilesArtifactID) error {
+	filesArtifactIdStr := string(filesArtifactId)
+	if strings.TrimSpace(filesArtifactIdStr) == "" {
+		return stacktrace.NewError("Local files artifact IDs can't be empty")
+	}
+	if filesArtifactIdStr == "." || filesArtifactIdStr == ".." {
+		return stacktrace.NewError("Local files artifact ID '%v' isn't allowed", filesArtifactId)
+	}
+	if strings.ContainsAny(filesArtifactIdStr, "/" + string(filepath.Separator)) {
+		return stacktrace.NewError("Local files artifact ID '%v' can't contain a path separator", filesArtifactId)
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper methods
+// ====================================================================================================
+/*
+Gets the filepath (relative to the suite execution volume) of the GZ-compressed TAR archive of the given local files
+	artifact, packing it onto the suite execution volume if this is the first time it's been requested in the test
+
+NOTE: The local files artifacts mutex is held while packing, so that concurrently-started services can't pack the same
+	artifact twice
+ */
+func (networkCtx *NetworkContext) getOrPackLocalFilesArtifact(filesArtifactId services.FilesArtifactID) (string, error) {
+	networkCtx.localFilesArtifactsMutex.Lock()
+	defer networkCtx.localFilesArtifactsMutex.Unlock()
+
+	if archiveRelativeFilepath, found := networkCtx.packedLocalFilesArtifactRelativeFilepaths[filesArtifactId]; found {
+		return archiveRelativeFilepath, nil
+	}
+
+	srcPath, found := networkCtx.localFilesArtifactPaths[filesArtifactId]
+	if !found {
+		return "", stacktrace.NewError("No local files artifact with ID '%v' was declared", filesArtifactId)
+	}
+
+	if err := os.MkdirAll(networkCtx.localFilesArtifactsDirpath, os.ModePerm); err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred creating local files artifacts directory '%v'", networkCtx.localFilesArtifactsDirpath)
+	}
+	// The filename is randomized so that tests sharing the suite execution volume can't clobber each other's archives
+	archiveFp, err := ioutil.TempFile(networkCtx.localFilesArtifactsDirpath, string(filesArtifactId) + "-*" + tgzArchiveExtension)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred creating the archive file for local files artifact '%v'", filesArtifactId)
+	}
+	defer archiveFp.Close()
+
+	logrus.Debugf("Packing local files artifact '%v' from '%v' into '%v'...", filesArtifactId, srcPath, archiveFp.Name())
+	if err := packLocalFilesArtifact(srcPath, archiveFp); err != nil {
+		if removeErr := os.Remove(archiveFp.Name()); removeErr != nil {
+			logrus.Warnf("An error occurred removing partially-packed archive '%v': %v", archiveFp.Name(), removeErr)
+		}
+		return "", stacktrace.Propagate(err, "An error occurred packing local files artifact '%v' from '%v'", filesArtifactId, srcPath)
+	}
+	logrus.Debugf("Successfully packed local files artifact '%v'", filesArtifactId)
+
+	archiveRelativeFilepath, err := filepath.Rel(test_suite_container_mountpoints.SuiteExVolMountpoint, archiveFp.Name())
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred getting the archive filepath relative to the suite execution volume")
+	}
+	networkCtx.packedLocalFilesArtifactRelativeFilepaths[filesArtifactId] = archiveRelativeFilepath
+	return archiveRelativeFilepath, nil
+}
+
+/*
+Writes the given directory or TAR archive as a GZ-compressed TAR archive, which is the only format the Kurtosis API
+	supports for files artifacts. Directories are packed so that their contents (rather than the directory itself) are
+	at the root of the archive.
+ */
+func packLocalFilesArtifact(srcPath string, destWriter io.Writer) error {
+	srcInfo, err := os.Stat(srcPath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting info about '%v'", srcPath)
+	}
+
+	if srcInfo.IsDir() {
+		gzipWriter := gzip.NewWriter(destWriter)
+		if err := packDirectory(srcPath, gzipWriter); err != nil {
+			return stacktrace.Propagate(err, "An error occurred packing directory '%v'", srcPath)
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return stacktrace.Propagate(err, "An error occurred finishing the compression of directory '%v'", srcPath)
+		}
+		return nil
+	}
+
+	srcFp, err := os.Open(srcPath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred opening archive '%v'", srcPath)
+	}
+	defer srcFp.Close()
+
+	lowercaseSrcPath := strings.ToLower(srcPath)
+	switch {
+	case strings.HasSuffix(lowercaseSrcPath, tgzArchiveExtension), strings.HasSuffix(lowercaseSrcPath, tarGzArchiveExtension):
+		if _, err := io.Copy(destWriter, srcFp); err != nil {
+			return stacktrace.Propagate(err, "An error occurred copying archive '%v'", srcPath)
+		}
+		return nil
+	case strings.HasSuffix(lowercaseSrcPath, tarArchiveExtension):
+		gzipWriter := gzip.NewWriter(destWriter)
+		if _, err := io.Copy(gzipWriter, srcFp); err != nil {
+			return stacktrace.Propagate(err, "An error occurred compressing archive '%v'", srcPath)
+		}
+		if err := gzipWriter.Close(); err != nil {
+			return stacktrace.Propagate(err, "An error occurred finishing the compression of archive '%v'", srcPath)
+		}
+		return nil
+	default:
+		return stacktrace.NewError(
+			"'%v' isn't a directory or an archive with extension %v, %v, or %v",
+			srcPath,
+			tarArchiveExtension,
+			tgzArchiveExtension,
+			tarGzArchiveExtension)
+	}
+}
+
+// Writes the contents of the given directory to the writer as a TAR archive, keeping their modes & symlinks
+func packDirectory(srcDirpath string, destWriter io.Writer) error {
+	tarWriter := tar.NewWriter(destWriter)
+	walkErr := filepath.Walk(srcDirpath, func(entryPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred walking to '%v'", entryPath)
+		}
+		relativePath, err := filepath.Rel(srcDirpath, entryPath)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred getting the path of '%v' relative to '%v'", entryPath, srcDirpath)
+		}
+		if relativePath == "." {
+			return nil
+		}
+
+		linkTarget := ""
+		if info.Mode() & os.ModeSymlink != 0 {
+			linkTarget, err = os.Readlink(entryPath)
+			if err != nil {
+				return stacktrace.Propagate(err, "An error occurred reading symlink '%v'", entryPath)
+			}
+		} else if !info.IsDir() && !info.Mode().IsRegular() {
+			return stacktrace.NewError("'%v' isn't a regular file, directory, or symlink", entryPath)
+		}
+
+		header, err := tar.FileInfoHeader(info, linkTarget)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred creating the TAR header for '%v'", entryPath)
+		}
+		header.Name = filepath.ToSlash(relativePath)
+		if info.IsDir() {
+			header.Name += "/"
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return stacktrace.Propagate(err, "An error occurred writing the TAR header for '%v'", entryPath)
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		entryFp, err := os.Open(entryPath)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred opening file '%v'", entryPath)
+		}
+		defer entryFp.Close()
+		if _, err := io.Copy(tarWriter, entryFp); err != nil {
+			return stacktrace.Propagate(err, "An error occurred writing the contents of file '%v'", entryPath)
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return stacktrace.Propagate(walkErr, "An error occurred packing the contents of directory '%v'", srcDirpath)
+	}
+	if err := tarWriter.Close(); err != nil {
+		return stacktrace.Propagate(err, "An error occurred finishing the TAR archive of directory '%v'", srcDirpath)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/kurtosis-tech/kurtosis-go/lib/test_suite_docker_consts/test_suite_container_mountpoints"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+const (
+	testLocalFilesArtifactId services.FilesArtifactID = "static-files"
+)
+
+func newLocalFilesArtifactTestInitializer(mountDirpath string) *services.MockDockerContainerInitializer {
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.FilesArtifactMountpoints = map[services.FilesArtifactID]string{testLocalFilesArtifactId: mountDirpath}
+	return initializer
+}
+
+func TestPackLocalFilesArtifactFromDirectory(t *testing.T) {
+	srcDirpath := t.TempDir()
+	if err := os.MkdirAll(path.Join(srcDirpath, "config"), 0755); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the source subdirectory"))
+	}
+	if err := os.WriteFile(path.Join(srcDirpath, "config", "app.yml"), []byte("port: 80"), 0644); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the source config file"))
+	}
+	if err := os.WriteFile(path.Join(srcDirpath, "run.sh"), []byte("#!/bin/sh"), 0755); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the source script"))
+	}
+	if err := os.Symlink("config/app.yml", path.Join(srcDirpath, "app.yml")); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the source symlink"))
+	}
+
+	archive := &bytes.Buffer{}
+	if err := packLocalFilesArtifact(srcDirpath, archive); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred packing the directory"))
+	}
+
+	headers, contents := readTgz(t, archive)
+	entryNames := []string{}
+	for name := range headers {
+		entryNames = append(entryNames, name)
+	}
+	sort.Strings(entryNames)
+	assert.Equal(t, []string{"app.yml", "config/", "config/app.yml", "run.sh"}, entryNames)
+	assert.Equal(t, "config/app.yml", headers["app.yml"].Linkname)
+	assert.Equal(t, byte(tar.TypeDir), headers["config/"].Typeflag)
+	assert.Equal(t, "port: 80", contents["config/app.yml"])
+	assert.Equal(t, int64(0755), headers["run.sh"].Mode)
+}
+
+func TestPackLocalFilesArtifactFromArchives(t *testing.T) {
+	tarContents := &bytes.Buffer{}
+	tarWriter := tar.NewWriter(tarContents)
+	fileContents := []byte("hello")
+	if err := tarWriter.WriteHeader(&tar.Header{Name: "hello.txt", Mode: 0644, Size: int64(len(fileContents))}); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the TAR header"))
+	}
+	if _, err := tarWriter.Write(fileContents); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the TAR contents"))
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred closing the TAR writer"))
+	}
+	tgzContents := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(tgzContents)
+	if _, err := gzipWriter.Write(tarContents.Bytes()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred compressing the TAR"))
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred closing the gzip writer"))
+	}
+
+	srcDirpath := t.TempDir()
+	archiveContents := map[string][]byte{
+		"files.tar": tarContents.Bytes(),
+		"files.tgz": tgzContents.Bytes(),
+		"files.TAR.GZ": tgzContents.Bytes(),
+	}
+	for filename, contents := range archiveContents {
+		srcFilepath := path.Join(srcDirpath, filename)
+		if err := os.WriteFile(srcFilepath, contents, 0644); err != nil {
+			t.Fatal(stacktrace.Propagate(err, "An error occurred writing archive '%v'", filename))
+		}
+
+		archive := &bytes.Buffer{}
+		if err := packLocalFilesArtifact(srcFilepath, archive); err != nil {
+			t.Fatal(stacktrace.Propagate(err, "An error occurred packing archive '%v'", filename))
+		}
+		_, contents := readTgz(t, archive)
+		assert.Equal(t, map[string]string{"hello.txt": "hello"}, contents, "Unexpected contents for archive '%v'", filename)
+	}
+
+	zipFilepath := path.Join(srcDirpath, "files.zip")
+	if err := os.WriteFile(zipFilepath, []byte{}, 0644); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the zip file"))
+	}
+	err := packLocalFilesArtifact(zipFilepath, &bytes.Buffer{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "isn't a directory or an archive")
+}
+
+func TestAddServicesMountLocalFilesArtifact(t *testing.T) {
+	srcDirpath := t.TempDir()
+	if err := os.WriteFile(path.Join(srcDirpath, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the source file"))
+	}
+
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(
+		client,
+		map[services.FilesArtifactID]string{},
+		map[services.FilesArtifactID]string{testLocalFilesArtifactId: srcDirpath})
+	networkCtx.localFilesArtifactsDirpath = t.TempDir()
+
+	if _, _, err := networkCtx.AddService(service1, newLocalFilesArtifactTestInitializer("/static")); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the first service"))
+	}
+	firstArgs := client.lastStartServiceArgs
+	assert.Empty(t, firstArgs.FilesArtifactMountDirpaths)
+	assert.Equal(t, 1, len(firstArgs.LocalFilesArtifactMountDirpaths))
+
+	if _, _, err := networkCtx.AddService(service2, newLocalFilesArtifactTestInitializer("/var/www")); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the second service"))
+	}
+	secondArgs := client.lastStartServiceArgs
+
+	// The artifact should only have been packed once, and reused by the second service
+	archiveFilepaths, err := filepath.Glob(path.Join(networkCtx.localFilesArtifactsDirpath, "*"))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred listing the packed archives"))
+	}
+	assert.Equal(t, 1, len(archiveFilepaths))
+	archiveRelativeFilepath, err := filepath.Rel(test_suite_container_mountpoints.SuiteExVolMountpoint, archiveFilepaths[0])
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the archive's relative filepath"))
+	}
+	assert.Equal(t, map[string]string{archiveRelativeFilepath: "/static"}, firstArgs.LocalFilesArtifactMountDirpaths)
+	assert.Equal(t, map[string]string{archiveRelativeFilepath: "/var/www"}, secondArgs.LocalFilesArtifactMountDirpaths)
+
+	archiveFp, err := os.Open(archiveFilepaths[0])
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred opening the packed archive"))
+	}
+	defer archiveFp.Close()
+	_, contents := readTgz(t, archiveFp)
+	assert.Equal(t, map[string]string{"index.html": "<html></html>"}, contents)
+}
+
+func TestAddServiceFailsForUnpackableLocalFilesArtifact(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(
+		client,
+		map[services.FilesArtifactID]string{},
+		map[services.FilesArtifactID]string{testLocalFilesArtifactId: path.Join(t.TempDir(), "nonexistent")})
+	networkCtx.localFilesArtifactsDirpath = t.TempDir()
+
+	_, _, err := networkCtx.AddService(service1, newLocalFilesArtifactTestInitializer("/static"))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "An error occurred packing local files artifact 'static-files'")
+	assert.Nil(t, client.lastStartServiceArgs)
+
+	// The failed start should have been rolled back so the ID can be reused
+	_, err = networkCtx.GetServiceInfo(service1)
+	assert.Error(t, err)
+}
+
+func TestValidateLocalFilesArtifactId(t *testing.T) {
+	assert.NoError(t, ValidateLocalFilesArtifactId(testLocalFilesArtifactId))
+	assert.NoError(t, ValidateLocalFilesArtifactId("static.files-v1"))
+	assert.Error(t, ValidateLocalFilesArtifactId(""))
+	assert.Error(t, ValidateLocalFilesArtifactId(".."))
+	assert.Error(t, ValidateLocalFilesArtifactId("nested/files"))
+}
+
+func TestAddServiceRejectsInvalidLocalFilesArtifactId(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	nestedFilesArtifactId := services.FilesArtifactID("nested/files")
+	networkCtx := NewNetworkContext(
+		client,
+		map[services.FilesArtifactID]string{},
+		map[services.FilesArtifactID]string{nestedFilesArtifactId: t.TempDir()})
+	networkCtx.localFilesArtifactsDirpath = t.TempDir()
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.FilesArtifactMountpoints = map[services.FilesArtifactID]string{nestedFilesArtifactId: "/static"}
+
+	_, _, err := networkCtx.AddService(service1, initializer)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Local files artifact ID 'nested/files' can't contain a path separator")
+
+	// The invalid ID should have been caught before anything was registered
+	assert.Nil(t, client.lastRegisterServiceArgs)
+}
+
+// ====================================================================================================
+//                                       Private helper methods
+// ====================================================================================================
+// Reads a GZ-compressed TAR archive, returning the headers & regular file contents keyed by entry name
+func readTgz(t *testing.T, reader io.Reader) (map[string]*tar.Header, map[string]string) {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the gzip reader"))
+	}
+	tarReader := tar.NewReader(gzipReader)
+	headers := map[string]*tar.Header{}
+	contents := map[string]string{}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(stacktrace.Propagate(err, "An error occurred reading the next TAR header"))
+		}
+		headers[header.Name] = header
+		if header.Typeflag == tar.TypeReg {
+			entryContents, err := io.ReadAll(tarReader)
+			if err != nil {
+				t.Fatal(stacktrace.Propagate(err, "An error occurred reading TAR entry '%v'", header.Name))
+			}
+			contents[header.Name] = string(entryContents)
+		}
+	}
+	return headers, contents
+}
@@ -6,32 +6,89 @@
 package networks
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"github.com/kurtosis-tech/kurtosis-go/lib/core_api/bindings"
 	"github.com/kurtosis-tech/kurtosis-go/lib/services"
 	"github.com/kurtosis-tech/kurtosis-go/lib/test_suite_docker_consts/test_suite_container_mountpoints"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
+	"math"
 	"os"
 	"path"
+	"strings"
 	"sync"
+	"time"
 )
 
 const (
 	// This will alwyas resolve to the default partition ID (regardless of whether such a partition exists in the network,
 	//  or it was repartitioned away)
 	defaultPartitionId PartitionID = ""
+
+	// Jobs have already exited by the time they're removed, so there's nothing to wait for
+	jobContainerStopTimeoutSeconds = 0
+
+	// Suffix for the directory that an archive will be extracted to, if the archive has no extension to strip
+	extractedArchiveDirSuffix = "-extracted"
+
+	// The mode that files to mount are created with, before the initializer gets them
+	mountedFileMode os.FileMode = 0644
 )
 
+// The initializer methods needed to register & start a container, shared by DockerContainerInitializer
+//  and DockerJobInitializer
+type containerInitializer interface {
+	GetDockerImage() string
+	GetFilesToMount() map[string]bool
+	InitializeMountedFiles(mountedFiles map[string]*os.File) error
+	GetFilesArtifactMountpoints() map[services.FilesArtifactID]string
+	GetTestVolumeMountpoint() string
+	GetStartCommand(mountedFileFilepaths map[string]string, ipAddr string) ([]string, error)
+}
+
 type NetworkContext struct {
 	client bindings.TestExecutionServiceClient
 
 	filesArtifactUrls map[services.FilesArtifactID]string
 
-	// Mutex protecting access to the services map
+	// Mapping of filesArtifactId -> path of the directory or TAR archive on the testsuite container
+	localFilesArtifactPaths map[services.FilesArtifactID]string
+
+	// Directory on the suite execution volume where local files artifacts are packed
+	localFilesArtifactsDirpath string
+
+	// Mutex protecting the packed local files artifacts, which is separate from the main mutex because packing a large
+	//  directory can take a while
+	localFilesArtifactsMutex *sync.Mutex
+
+	// Mapping of filesArtifactId -> archive filepath (relative to the suite execution volume) of the local files
+	//  artifacts that have been packed so far in the test
+	packedLocalFilesArtifactRelativeFilepaths map[services.FilesArtifactID]string
+
+	// Mutex protecting access to the services, pending IDs, service infos, availability checkers, shared volumes, and
+	//  health monitor fields
+	// NOTE: This is deliberately NOT held during calls to the Kurtosis API that start containers, so that multiple
+	//  services can be started concurrently
 	mutex *sync.Mutex
 
+	// "Set" of IDs of services & jobs that are in the process of being started, which are reserved so that two
+	//  concurrent callers can't start containers with the same ID
+	pendingServiceIds map[services.ServiceID]bool
+
 	services map[services.ServiceID]services.Service
+
+	serviceInfos map[services.ServiceID]*ServiceInfo
+
+	serviceAvailabilityCheckers map[services.ServiceID]services.AvailabilityChecker
+
+	// Mapping of sharedVolumeId -> dirpath (relative to the suite execution volume) of the shared volumes that have been
+	//  created so far in the test
+	sharedVolumeRelativeDirpaths map[services.SharedVolumeID]string
+
+	// Nil if health monitoring isn't running
+	healthMonitor *ServiceHealthMonitor
 }
 
 
@@ -41,41 +98,123 @@ Creates a new NetworkContext object with the given parameters.
 Args:
 	client: The Kurtosis API client that the NetworkContext will use for modifying the state of the testnet
 	filesArtifactUrls: The mapping of filesArtifactId -> URL for the artifacts that the testsuite will use
+	localFilesArtifactPaths: The mapping of filesArtifactId -> path on the testsuite container of the directory or TAR
+		archive for the local artifacts that the testsuite will use
 */
 func NewNetworkContext(
 		client bindings.TestExecutionServiceClient,
-		filesArtifactUrls map[services.FilesArtifactID]string) *NetworkContext {
+		filesArtifactUrls map[services.FilesArtifactID]string,
+		localFilesArtifactPaths map[services.FilesArtifactID]string) *NetworkContext {
 	return &NetworkContext{
 		mutex: &sync.Mutex{},
 		client: client,
 		filesArtifactUrls: filesArtifactUrls,
+		localFilesArtifactPaths: localFilesArtifactPaths,
+		localFilesArtifactsDirpath: path.Join(test_suite_container_mountpoints.SuiteExVolMountpoint, localFilesArtifactsDirname),
+		localFilesArtifactsMutex: &sync.Mutex{},
+		packedLocalFilesArtifactRelativeFilepaths: map[services.FilesArtifactID]string{},
 		services: map[services.ServiceID]services.Service{},
+		pendingServiceIds: map[services.ServiceID]bool{},
+		serviceInfos: map[services.ServiceID]*ServiceInfo{},
+		serviceAvailabilityCheckers: map[services.ServiceID]services.AvailabilityChecker{},
+		sharedVolumeRelativeDirpaths: map[services.SharedVolumeID]string{},
+		healthMonitor: nil,
 	}
 }
 
 /*
-Adds a service to the network in the default partition with the given service ID
+Adds a service to the network with the given service ID, in the default partition unless the WithPartition option
+	is given.
 
-NOTE: If the network has been repartitioned and the default partition hasn't been preserved, you should use
-	AddServiceToPartition instead.
+NOTE: If the network has been repartitioned and the default partition hasn't been preserved, you should use the
+	WithPartition option.
 
 Args:
 	serviceId: The service ID that will be used to identify this node in the network.
 	initializer: The Docker container initializer that contains the logic for starting the service
+	options: Options customizing how the service is added (see add_service_options.go)
 
 Return:
 	service: The new service
+	availabilityChecker: The checker for polling whether the service is available
+	error: An error if the service couldn't be added, in which case nothing is left behind: a service that was
+		registered but couldn't be started, or that didn't become available in time (see WithStartupWait), is removed
+		so that the service ID can be reused
 */
 func (networkCtx *NetworkContext) AddService(
 		serviceId services.ServiceID,
-		initializer services.DockerContainerInitializer) (services.Service, services.AvailabilityChecker, error) {
-	// Go mutexes aren't re-entrant, so we lock the mutex inside this call
-	service, availabilityChecker, err := networkCtx.AddServiceToPartition(
+		initializer services.DockerContainerInitializer,
+		options ...AddServiceOption) (services.Service, services.AvailabilityChecker, error) {
+	config := newAddServiceConfig(options)
+
+	if err := networkCtx.reserveServiceId(serviceId); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred reserving service ID '%v'", serviceId)
+	}
+	defer networkCtx.releaseServiceIdReservation(serviceId)
+
+	resourceLimits := services.GetInitializerResourceLimits(initializer)
+	if config.resourceLimits != nil {
+		resourceLimits = *config.resourceLimits
+	}
+
+	usedPorts := initializer.GetUsedPorts()
+	serviceIpAddr, startCmdArgs, err := networkCtx.registerAndStartContainer(
 		serviceId,
-		defaultPartitionId,
-		initializer)
+		config.partitionId,
+		initializer,
+		services.GetInitializerFileTemplates(initializer),
+		services.GetInitializerGeneratedFiles(initializer),
+		usedPorts,
+		config.envVars,
+		resourceLimits)
 	if err != nil {
-		return nil, nil, stacktrace.Propagate(err, "An error occurred adding service '%v' to the network in the default partition", serviceId)
+		return nil, nil, stacktrace.Propagate(err, "An error occurred registering and starting the container for service '%v'", serviceId)
+	}
+
+	logrus.Tracef("Creating service interface...")
+	service := initializer.GetService(serviceId, serviceIpAddr)
+	logrus.Tracef("Successfully created service interface")
+
+	availabilityCheck := services.GetInitializerAvailabilityCheck(initializer)
+	containerAccessor := newServiceContainerAccessor(networkCtx.client, serviceId)
+	availabilityChecker := services.NewAvailabilityCheckerWithCheck(serviceId, service, availabilityCheck, containerAccessor)
+
+	serviceInfo := &ServiceInfo{
+		ServiceID:                serviceId,
+		IPAddress:                serviceIpAddr,
+		DockerImage:              initializer.GetDockerImage(),
+		StartCommand:             startCmdArgs,
+		UsedPorts:                usedPorts,
+		PartitionID:              config.partitionId,
+		FilesArtifactMountpoints: initializer.GetFilesArtifactMountpoints(),
+		SharedVolumeMountpoints:  services.GetInitializerSharedVolumeMountpoints(initializer),
+		Labels:                   config.labels,
+		StartTime:                time.Now(),
+	}
+
+	networkCtx.mutex.Lock()
+	networkCtx.services[serviceId] = service
+	networkCtx.serviceInfos[serviceId] = serviceInfo
+	networkCtx.serviceAvailabilityCheckers[serviceId] = availabilityChecker
+	if networkCtx.healthMonitor != nil {
+		networkCtx.healthMonitor.onServiceAdded(serviceId)
+	}
+	networkCtx.mutex.Unlock()
+
+	if config.startupWait != nil {
+		ctx, cancelFunc := context.WithTimeout(context.Background(), config.startupWait.timeout)
+		defer cancelFunc()
+		if err := availabilityChecker.WaitForStartupWithContext(ctx, config.startupWait.backoff); err != nil {
+			if removeErr := networkCtx.RemoveService(serviceId, 0); removeErr != nil {
+				return nil, nil, stacktrace.Propagate(
+					err,
+					"An error occurred waiting for service '%v' to become available, and removing it also failed so " +
+						"the service may need to be removed manually; the removal error was:\n%v",
+					serviceId,
+					removeErr)
+			}
+			return nil, nil, stacktrace.Propagate(err, "An error occurred waiting for service '%v' to become available, so it was removed", serviceId)
+		}
 	}
 
 	return service, availabilityChecker, nil
@@ -84,8 +223,8 @@ func (networkCtx *NetworkContext) AddService(
 /*
 Adds a service to the network with the given service ID, created using the given configuration ID.
 
-NOTE: If the network hasn't been repartitioned yet, the PartitionID should be an empty string to add to the default
-	partition.
+NOTE: This is equivalent to calling AddService with the WithPartition option. If the network hasn't been repartitioned
+	yet, the PartitionID should be an empty string to add to the default partition.
 
 Args:
 	serviceId: The service ID that will be used to identify this node in the network.
@@ -99,116 +238,298 @@ func (networkCtx *NetworkContext) AddServiceToPartition(
 		serviceId services.ServiceID,
 		partitionId PartitionID,
 		initializer services.DockerContainerInitializer) (services.Service, services.AvailabilityChecker, error) {
-	networkCtx.mutex.Lock()
-	defer networkCtx.mutex.Unlock()
+	service, availabilityChecker, err := networkCtx.AddService(serviceId, initializer, WithPartition(partitionId))
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred adding service '%v' to partition '%v'", serviceId, partitionId)
+	}
+	return service, availabilityChecker, nil
+}
 
-	ctx := context.Background()
+/*
+Adds the given services to the network in the default partition, starting them all in parallel.
 
-	logrus.Tracef("Registering new service ID with Kurtosis API...")
-	registerServiceArgs := &bindings.RegisterServiceArgs{
-		ServiceId:       string(serviceId),
-		PartitionId:     string(partitionId),
-		FilesToGenerate: initializer.GetFilesToMount(),
-	}
-	registerServiceResp, err := networkCtx.client.RegisterService(ctx, registerServiceArgs)
+NOTE: If the network has been repartitioned and the default partition hasn't been preserved, you should use
+	AddServicesToPartition instead.
+
+Args:
+	initializers: Mapping of serviceId -> the Docker container initializer containing the logic for starting the service
+
+Return:
+	The services that were successfully added, their availability checkers, and an error listing every service that
+		failed to be added (if any), whose root cause is an *AddServicesError. Services that were added successfully
+		remain in the network even if others failed.
+ */
+func (networkCtx *NetworkContext) AddServices(
+		initializers map[services.ServiceID]services.DockerContainerInitializer) (
+			map[services.ServiceID]services.Service,
+			map[services.ServiceID]services.AvailabilityChecker,
+			error) {
+	addedServices, availabilityCheckers, err := networkCtx.AddServicesToPartition(defaultPartitionId, initializers)
 	if err != nil {
-		return nil, nil, stacktrace.Propagate(
-			err,
-			"An error occurred registering service with ID '%v' with the Kurtosis API",
-			serviceId)
+		return addedServices, availabilityCheckers, stacktrace.Propagate(err, "An error occurred adding services to the network in the default partition")
 	}
-	logrus.Tracef("New service successfully registered with Kurtosis API")
+	return addedServices, availabilityCheckers, nil
+}
 
-	suiteExVolMountpointOnService := initializer.GetTestVolumeMountpoint()
-	generatedFilesRelativeFilepaths := registerServiceResp.GeneratedFilesRelativeFilepaths
-	generatedFilesFps := map[string]*os.File{}
-	generatedFilesAbsoluteFilepathsOnService := map[string]string{}
-	for fileId, relativeFilepath := range generatedFilesRelativeFilepaths {
-		absoluteFilepathOnTestsuite := path.Join(test_suite_container_mountpoints.SuiteExVolMountpoint, relativeFilepath)
-		logrus.Debugf("Opening generated file at '%v' for writing...", absoluteFilepathOnTestsuite)
-		fp, err := os.Create(absoluteFilepathOnTestsuite)
-		if err != nil {
-			return nil, nil, stacktrace.Propagate(
-				err,
-				"Could not open generated file '%v' for writing",
-				fileId)
-		}
-		defer fp.Close()
-		generatedFilesFps[fileId] = fp
+/*
+Adds the given services to the network in the given partition, starting them all in parallel.
 
-		absoluteFilepathOnService := path.Join(suiteExVolMountpointOnService, relativeFilepath)
-		generatedFilesAbsoluteFilepathsOnService[fileId] = absoluteFilepathOnService
+Args:
+	partitionId: The partition ID to add the services to
+	initializers: Mapping of serviceId -> the Docker container initializer containing the logic for starting the service
+
+Return:
+	The services that were successfully added, their availability checkers, and an error listing every service that
+		failed to be added (if any), whose root cause is an *AddServicesError. Services that were added successfully
+		remain in the network even if others failed.
+ */
+func (networkCtx *NetworkContext) AddServicesToPartition(
+		partitionId PartitionID,
+		initializers map[services.ServiceID]services.DockerContainerInitializer) (
+			map[services.ServiceID]services.Service,
+			map[services.ServiceID]services.AvailabilityChecker,
+			error) {
+	resultsMutex := &sync.Mutex{}
+	addedServices := map[services.ServiceID]services.Service{}
+	availabilityCheckers := map[services.ServiceID]services.AvailabilityChecker{}
+	failures := map[services.ServiceID]error{}
+
+	waitGroup := sync.WaitGroup{}
+	for serviceId, initializer := range initializers {
+		waitGroup.Add(1)
+		go func(serviceId services.ServiceID, initializer services.DockerContainerInitializer) {
+			defer waitGroup.Done()
+			service, availabilityChecker, err := networkCtx.AddServiceToPartition(serviceId, partitionId, initializer)
+
+			resultsMutex.Lock()
+			defer resultsMutex.Unlock()
+			if err != nil {
+				failures[serviceId] = err
+				return
+			}
+			addedServices[serviceId] = service
+			availabilityCheckers[serviceId] = availabilityChecker
+		}(serviceId, initializer)
 	}
+	waitGroup.Wait()
 
-	logrus.Trace("Initializing generated files...")
-	if err := initializer.InitializeMountedFiles(generatedFilesFps); err != nil {
-		return nil, nil, stacktrace.Propagate(err, "An error occurred initializing the generated files")
+	if len(failures) > 0 {
+		return addedServices, availabilityCheckers, &AddServicesError{Failures: failures}
 	}
-	logrus.Trace("Successfully initialized generated files")
+	return addedServices, availabilityCheckers, nil
+}
 
+/*
+Gets the service with the given ID, or returns an error if no service with that ID exists.
+ */
+func (networkCtx *NetworkContext) GetService(serviceId services.ServiceID) (services.Service, error) {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
 
-	logrus.Tracef("Creating files artifact URL -> mount dirpaths map...")
-	artifactUrlToMountDirpath := map[string]string{}
-	for filesArtifactId, mountDirpath := range initializer.GetFilesArtifactMountpoints() {
-		artifactUrl, found := networkCtx.filesArtifactUrls[filesArtifactId]
-		if !found {
-			return nil, nil, stacktrace.Propagate(
-				err,
-				"Service requested file artifact '%v', but the network" +
-					"context doesn't have a URL for that file artifact; this is a bug with Kurtosis itself",
-				filesArtifactId)
-		}
-		artifactUrlToMountDirpath[string(artifactUrl)] = mountDirpath
+	service, found := networkCtx.services[serviceId]
+	if !found {
+		return nil, stacktrace.NewError("No service found with ID '%v'", serviceId)
 	}
-	logrus.Tracef("Successfully created files artifact URL -> mount dirpaths map")
 
-	logrus.Tracef("Creating start command for service...")
-	serviceIpAddr := registerServiceResp.IpAddr
-	startCmdArgs, err := initializer.GetStartCommand(generatedFilesAbsoluteFilepathsOnService, serviceIpAddr)
-	if err != nil {
-		return nil, nil, stacktrace.Propagate(err, "Failed to create start command")
+	return service, nil
+}
+
+/*
+Changes the resource limits of the given service's running container, e.g. to simulate resource starvation partway
+	through a test. The new limits replace the existing limits entirely, so any limit left as zero will be removed.
+ */
+func (networkCtx *NetworkContext) UpdateServiceResourceLimits(serviceId services.ServiceID, resourceLimits services.ResourceLimits) error {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	if _, found := networkCtx.services[serviceId]; !found {
+		return stacktrace.NewError("No service found with ID '%v'", serviceId)
 	}
-	logrus.Tracef("Successfully created start command for service")
 
-	logrus.Tracef("Starting new service with Kurtosis API...")
-	startServiceArgs := &bindings.StartServiceArgs{
-		ServiceId:                   string(serviceId),
-		DockerImage:                 initializer.GetDockerImage(),
-		UsedPorts:                   initializer.GetUsedPorts(),
-		StartCmdArgs:                startCmdArgs,
-		DockerEnvVars:               map[string]string{}, // TODO actually support Docker env vars!
-		SuiteExecutionVolMntDirpath: initializer.GetTestVolumeMountpoint(),
-		FilesArtifactMountDirpaths:  artifactUrlToMountDirpath,
+	args := &bindings.UpdateServiceResourceLimitsArgs{
+		ServiceId:      string(serviceId),
+		ResourceLimits: newResourceLimitsBinding(resourceLimits),
 	}
-	if _, err := networkCtx.client.StartService(ctx, startServiceArgs); err != nil {
-		return nil, nil, stacktrace.Propagate(err, "An error occurred starting the service with the Kurtosis API")
+	if _, err := networkCtx.client.UpdateServiceResourceLimits(context.Background(), args); err != nil {
+		return stacktrace.Propagate(err, "An error occurred updating the resource limits of service '%v'", serviceId)
 	}
-	logrus.Tracef("Successfully started service with Kurtosis API")
+	return nil
+}
 
-	logrus.Tracef("Creating service interface...")
-	service := initializer.GetService(serviceId, serviceIpAddr)
-	logrus.Tracef("Successfully created service interface")
+/*
+Starts probing the availability of every service in the network (including services added later) in the background,
+	recording every change in each service's health.
 
-	networkCtx.services[serviceId] = service
+Args:
+	pollInterval: How often each service will be probed
+	onUnexpectedDown: If non-nil, called whenever a service goes from available to unavailable without being declared
+		as expected to go down via ServiceHealthMonitor.ExpectServiceDown
+
+Returns:
+	The running monitor, which can also be retrieved later via GetHealthMonitor
+ */
+func (networkCtx *NetworkContext) StartHealthMonitor(pollInterval time.Duration, onUnexpectedDown func(err error)) (*ServiceHealthMonitor, error) {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
 
-	availabilityChecker := services.NewDefaultAvailabilityChecker(serviceId, service)
+	if networkCtx.healthMonitor != nil {
+		return nil, stacktrace.NewError("Cannot start a health monitor because one is already running")
+	}
+	monitor := newServiceHealthMonitor(networkCtx, pollInterval, onUnexpectedDown)
+	monitor.start()
+	networkCtx.healthMonitor = monitor
+	return monitor, nil
+}
 
-	return service, availabilityChecker, nil
+/*
+Stops the running health monitor, if any, cancelling any in-progress probes and blocking until they've finished
+ */
+func (networkCtx *NetworkContext) StopHealthMonitor() {
+	networkCtx.mutex.Lock()
+	monitor := networkCtx.healthMonitor
+	networkCtx.healthMonitor = nil
+	networkCtx.mutex.Unlock()
+
+	// The mutex can't be held while stopping because in-progress probes need it to finish
+	if monitor != nil {
+		monitor.stop()
+	}
 }
 
 /*
-Gets the service with the given ID, or returns an error if no service with that ID exists.
+Gets the running health monitor, or nil if health monitoring isn't running
  */
-func (networkCtx *NetworkContext) GetService(serviceId services.ServiceID) (services.Service, error) {
+func (networkCtx *NetworkContext) GetHealthMonitor() *ServiceHealthMonitor {
 	networkCtx.mutex.Lock()
 	defer networkCtx.mutex.Unlock()
 
-	service, found := networkCtx.services[serviceId]
+	return networkCtx.healthMonitor
+}
+
+/*
+Gets the IDs of all the services currently in the network
+ */
+func (networkCtx *NetworkContext) GetServiceIDs() map[services.ServiceID]bool {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	result := map[services.ServiceID]bool{}
+	for serviceId := range networkCtx.services {
+		result[serviceId] = true
+	}
+	return result
+}
+
+/*
+Gets the ports that the service with the given ID listens on
+ */
+func (networkCtx *NetworkContext) GetServiceUsedPorts(serviceId services.ServiceID) ([]services.Port, error) {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	serviceInfo, found := networkCtx.serviceInfos[serviceId]
 	if !found {
 		return nil, stacktrace.NewError("No service found with ID '%v'", serviceId)
 	}
+	return serviceInfo.clone().UsedPorts, nil
+}
 
-	return service, nil
+/*
+Gets the port with the given name (as declared by the service's initializer) of the service with the given ID, e.g.:
+
+	httpPort, err := networkCtx.GetServicePort(serviceId, "http")
+ */
+func (networkCtx *NetworkContext) GetServicePort(serviceId services.ServiceID, portName string) (services.Port, error) {
+	usedPorts, err := networkCtx.GetServiceUsedPorts(serviceId)
+	if err != nil {
+		return services.Port{}, stacktrace.Propagate(err, "An error occurred getting the ports of service '%v'", serviceId)
+	}
+	port, err := services.GetPortByName(usedPorts, portName)
+	if err != nil {
+		return services.Port{}, stacktrace.Propagate(err, "An error occurred getting port '%v' of service '%v'", portName, serviceId)
+	}
+	return port, nil
+}
+
+/*
+Gets the key/value labels that were attached to the service with the given ID via the WithLabels option
+ */
+func (networkCtx *NetworkContext) GetServiceLabels(serviceId services.ServiceID) (map[string]string, error) {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	serviceInfo, found := networkCtx.serviceInfos[serviceId]
+	if !found {
+		return nil, stacktrace.NewError("No service found with ID '%v'", serviceId)
+	}
+	return serviceInfo.clone().Labels, nil
+}
+
+/*
+Gets information about the service with the given ID, such as its IP, image, start command, ports, and partition
+ */
+func (networkCtx *NetworkContext) GetServiceInfo(serviceId services.ServiceID) (*ServiceInfo, error) {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	serviceInfo, found := networkCtx.serviceInfos[serviceId]
+	if !found {
+		return nil, stacktrace.NewError("No service found with ID '%v'", serviceId)
+	}
+	return serviceInfo.clone(), nil
+}
+
+/*
+Gets information about every service currently in the network
+
+Return:
+	Mapping of serviceId -> info about the service
+ */
+func (networkCtx *NetworkContext) GetAllServiceInfo() map[services.ServiceID]*ServiceInfo {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	result := map[services.ServiceID]*ServiceInfo{}
+	for serviceId, serviceInfo := range networkCtx.serviceInfos {
+		result[serviceId] = serviceInfo.clone()
+	}
+	return result
+}
+
+/*
+Gets the IDs of all the services whose labels match the given selector, sorted so that they can be passed directly to
+	e.g. RepartitionerBuilder.WithPartition to target "all validators" rather than hard-coded IDs
+ */
+func (networkCtx *NetworkContext) GetServiceIDsByLabels(selector *LabelSelector) []services.ServiceID {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	matchingServiceIds := map[services.ServiceID]bool{}
+	for serviceId, serviceInfo := range networkCtx.serviceInfos {
+		if selector.Matches(serviceInfo.Labels) {
+			matchingServiceIds[serviceId] = true
+		}
+	}
+	return getSortedServiceIds(matchingServiceIds)
+}
+
+/*
+Gets all the services whose labels match the given selector
+
+Return:
+	Mapping of serviceId -> service, for each matching service
+ */
+func (networkCtx *NetworkContext) GetServicesByLabels(selector *LabelSelector) map[services.ServiceID]services.Service {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	result := map[services.ServiceID]services.Service{}
+	for serviceId, serviceInfo := range networkCtx.serviceInfos {
+		if selector.Matches(serviceInfo.Labels) {
+			result[serviceId] = networkCtx.services[serviceId]
+		}
+	}
+	return result
 }
 
 /*
@@ -230,10 +551,138 @@ func (networkCtx *NetworkContext) RemoveService(serviceId services.ServiceID, co
 		return stacktrace.Propagate(err, "An error occurred removing service '%v' from the network", serviceId)
 	}
 	delete(networkCtx.services, serviceId)
+	delete(networkCtx.serviceInfos, serviceId)
+	delete(networkCtx.serviceAvailabilityCheckers, serviceId)
+	if networkCtx.healthMonitor != nil {
+		networkCtx.healthMonitor.onServiceRemoved(serviceId)
+	}
 	logrus.Debugf("Successfully removed service ID %v", serviceId)
 	return nil
 }
 
+/*
+Copies a file or directory out of the container of the given service and onto the suite execution volume, so that
+	the testsuite can inspect it or keep it as an artifact. Directories are transferred as a TAR archive and
+	extracted with their structure intact.
+
+Args:
+	serviceId: The ID of the service to copy from
+	containerPath: The absolute path of the file or directory inside the service's container, which can't be the root
+		of the container
+
+Return:
+	The absolute path *on the testsuite container* (underneath the suite execution volume mountpoint) where the copied
+		file or directory can be read from
+ */
+func (networkCtx *NetworkContext) CopyFromService(serviceId services.ServiceID, containerPath string) (string, error) {
+	if !path.IsAbs(containerPath) {
+		return "", stacktrace.NewError("Container path '%v' must be absolute", containerPath)
+	}
+
+	// We only hold the mutex while checking the service exists, since the copy itself can take a while
+	networkCtx.mutex.Lock()
+	_, found := networkCtx.services[serviceId]
+	networkCtx.mutex.Unlock()
+	if !found {
+		return "", stacktrace.NewError("No service found with ID '%v'", serviceId)
+	}
+
+	copiedPath, err := networkCtx.copyFromContainer(serviceId, containerPath)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred copying path '%v' out of service '%v'", containerPath, serviceId)
+	}
+	return copiedPath, nil
+}
+
+/*
+Runs a one-shot job container in the default partition, waiting for it to exit.
+
+NOTE: If the network has been repartitioned and the default partition hasn't been preserved, you should use
+	RunJobInPartition instead.
+
+Args:
+	jobId: The ID that will be used to identify the job's container in the network. This shares a namespace with service IDs.
+	initializer: The Docker job initializer that contains the logic for starting the job
+	timeout: How long to wait for the job to exit before returning an error
+
+Return:
+	The result of the job, containing its exit code, logs, and output files
+ */
+func (networkCtx *NetworkContext) RunJob(
+		jobId services.ServiceID,
+		initializer services.DockerJobInitializer,
+		timeout time.Duration) (*services.JobResult, error) {
+	result, err := networkCtx.RunJobInPartition(jobId, defaultPartitionId, initializer, timeout)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred running job '%v' in the default partition", jobId)
+	}
+	return result, nil
+}
+
+/*
+Runs a one-shot job container in the given partition, waiting for it to exit. Once the job has exited, its output files
+	are copied to the suite execution volume and the job's container is removed from the network.
+
+NOTE: A nonzero exit code is NOT considered an error; it's up to the caller to inspect the exit code of the result.
+
+Args:
+	jobId: The ID that will be used to identify the job's container in the network. This shares a namespace with service IDs.
+	partitionId: The partition ID to run the job in
+	initializer: The Docker job initializer that contains the logic for starting the job
+	timeout: How long to wait for the job to exit before returning an error, which is rounded up to a whole number of
+		seconds
+
+Return:
+	The result of the job, containing its exit code, logs, and output files
+ */
+func (networkCtx *NetworkContext) RunJobInPartition(
+		jobId services.ServiceID,
+		partitionId PartitionID,
+		initializer services.DockerJobInitializer,
+		timeout time.Duration) (*services.JobResult, error) {
+	if timeout <= 0 {
+		return nil, stacktrace.NewError("The timeout for job '%v' must be positive, but was %v", jobId, timeout)
+	}
+
+	// The job's ID is reserved for the job's entire lifetime, so that no service or other job can take it
+	if err := networkCtx.reserveServiceId(jobId); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reserving ID '%v' for the job", jobId)
+	}
+	defer networkCtx.releaseServiceIdReservation(jobId)
+
+	// Jobs don't serve anything, so they don't listen on any ports
+	if _, _, err := networkCtx.registerAndStartContainer(
+			jobId,
+			partitionId,
+			initializer,
+			map[string]*services.FileTemplate{},
+			map[string]*services.GeneratedFile{},
+			[]services.Port{},
+			map[string]string{},
+			services.ResourceLimits{}); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred registering and starting the container for job '%v'", jobId)
+	}
+
+	result, err := networkCtx.collectJobResult(jobId, initializer, timeout)
+	// The job's container is removed regardless of whether collection succeeded, so it doesn't linger in the network
+	removeArgs := &bindings.RemoveServiceArgs{
+		ServiceId:                   string(jobId),
+		ContainerStopTimeoutSeconds: jobContainerStopTimeoutSeconds,
+	}
+	_, removeErr := networkCtx.client.RemoveService(context.Background(), removeArgs)
+	if err != nil {
+		if removeErr != nil {
+			logrus.Errorf("An error occurred removing the container of job '%v' after the job failed:", jobId)
+			fmt.Fprintln(logrus.StandardLogger().Out, removeErr)
+		}
+		return nil, stacktrace.Propagate(err, "An error occurred collecting the result of job '%v'", jobId)
+	}
+	if removeErr != nil {
+		return nil, stacktrace.Propagate(removeErr, "An error occurred removing the container of job '%v'", jobId)
+	}
+	return result, nil
+}
+
 /*
 Constructs a new repartitioner builder in preparation for a repartition.
 
@@ -289,5 +738,465 @@ func (networkCtx *NetworkContext) RepartitionNetwork(repartitioner *Repartitione
 	if _, err := networkCtx.client.Repartition(context.Background(), repartitionArgs); err != nil {
 		return stacktrace.Propagate(err, "An error occurred repartitioning the test network")
 	}
+
+	for partitionId, serviceIdSet := range repartitioner.partitionServices {
+		for _, serviceId := range serviceIdSet.getElems() {
+			if serviceInfo, found := networkCtx.serviceInfos[serviceId]; found {
+				serviceInfo.PartitionID = partitionId
+			}
+		}
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper methods
+// ====================================================================================================
+/*
+Registers a container with the Kurtosis API, initializes its generated files, and starts it. If anything fails after
+	the container is registered, the registration is rolled back so that the ID can be reused.
+NOTE: This function does not lock the mutex, so that containers can be started concurrently; callers should reserve
+	the ID first with reserveServiceId
+
+Returns:
+	The IP address of the started container, and the command it was started with
+ */
+func (networkCtx *NetworkContext) registerAndStartContainer(
+		serviceId services.ServiceID,
+		partitionId PartitionID,
+		initializer containerInitializer,
+		fileTemplates map[string]*services.FileTemplate,
+		generatedFiles map[string]*services.GeneratedFile,
+		usedPorts []services.Port,
+		envVars map[string]string,
+		resourceLimits services.ResourceLimits) (string, []string, error) {
+	ctx := context.Background()
+
+	if err := networkCtx.validateContainerInitializer(initializer, usedPorts, generatedFiles); err != nil {
+		return "", nil, stacktrace.Propagate(err, "The initializer for service '%v' is invalid", serviceId)
+	}
+
+	// Templated & generated files are created just like the files the initializer writes itself, so they share a key space
+	filesToGenerate := map[string]bool{}
+	for fileKey := range initializer.GetFilesToMount() {
+		filesToGenerate[fileKey] = true
+	}
+	for fileKey := range fileTemplates {
+		if filesToGenerate[fileKey] {
+			return "", nil, stacktrace.NewError(
+				"File key '%v' of service '%v' is declared both as a file to mount and as a file template",
+				fileKey,
+				serviceId)
+		}
+		filesToGenerate[fileKey] = true
+	}
+	for fileKey := range generatedFiles {
+		if filesToGenerate[fileKey] {
+			return "", nil, stacktrace.NewError(
+				"File key '%v' of service '%v' is declared both as a generated file and as a file to mount or file template",
+				fileKey,
+				serviceId)
+		}
+		filesToGenerate[fileKey] = true
+	}
+
+	logrus.Tracef("Registering new service ID with Kurtosis API...")
+	registerServiceArgs := &bindings.RegisterServiceArgs{
+		ServiceId:       string(serviceId),
+		PartitionId:     string(partitionId),
+		FilesToGenerate: filesToGenerate,
+	}
+	registerServiceResp, err := networkCtx.client.RegisterService(ctx, registerServiceArgs)
+	if err != nil {
+		return "", nil, stacktrace.Propagate(
+			err,
+			"An error occurred registering service with ID '%v' with the Kurtosis API",
+			serviceId)
+	}
+	logrus.Tracef("New service successfully registered with Kurtosis API")
+
+	serviceIpAddr, startCmdArgs, err := networkCtx.startRegisteredContainer(
+		serviceId,
+		initializer,
+		fileTemplates,
+		generatedFiles,
+		registerServiceResp,
+		usedPorts,
+		envVars,
+		resourceLimits)
+	if err != nil {
+		if rollbackErr := networkCtx.rollBackRegistration(serviceId, registerServiceResp.GeneratedFilesRelativeFilepaths); rollbackErr != nil {
+			return "", nil, stacktrace.Propagate(
+				err,
+				"An error occurred starting registered service '%v', and rolling back its registration also failed so " +
+					"the service may need to be removed manually; the rollback error was:\n%v",
+				serviceId,
+				rollbackErr)
+		}
+		return "", nil, stacktrace.Propagate(err, "An error occurred starting registered service '%v', so its registration was rolled back", serviceId)
+	}
+	return serviceIpAddr, startCmdArgs, nil
+}
+
+/*
+Initializes the generated files of a container that's been registered with the Kurtosis API, and starts it
+ */
+func (networkCtx *NetworkContext) startRegisteredContainer(
+		serviceId services.ServiceID,
+		initializer containerInitializer,
+		fileTemplates map[string]*services.FileTemplate,
+		generatedFiles map[string]*services.GeneratedFile,
+		registerServiceResp *bindings.RegisterServiceResponse,
+		usedPorts []services.Port,
+		envVars map[string]string,
+		resourceLimits services.ResourceLimits) (string, []string, error) {
+	ctx := context.Background()
+
+	suiteExVolMountpointOnService := initializer.GetTestVolumeMountpoint()
+	generatedFilesRelativeFilepaths := registerServiceResp.GeneratedFilesRelativeFilepaths
+	generatedFilesFps := map[string]*os.File{}
+	generatedFilesAbsoluteFilepathsOnService := map[string]string{}
+	for fileId, relativeFilepath := range generatedFilesRelativeFilepaths {
+		absoluteFilepathOnService := path.Join(suiteExVolMountpointOnService, relativeFilepath)
+		generatedFilesAbsoluteFilepathsOnService[fileId] = absoluteFilepathOnService
+
+		absoluteFilepathOnTestsuite := path.Join(test_suite_container_mountpoints.SuiteExVolMountpoint, relativeFilepath)
+		if generatedFile, found := generatedFiles[fileId]; found {
+			logrus.Debugf("Generating file '%v' at '%v'...", fileId, absoluteFilepathOnTestsuite)
+			if err := generatedFile.Generate(absoluteFilepathOnTestsuite); err != nil {
+				return "", nil, stacktrace.Propagate(err, "An error occurred generating file '%v'", fileId)
+			}
+			continue
+		}
+
+		logrus.Debugf("Opening generated file at '%v' for writing...", absoluteFilepathOnTestsuite)
+		fp, err := os.Create(absoluteFilepathOnTestsuite)
+		if err != nil {
+			return "", nil, stacktrace.Propagate(
+				err,
+				"Could not open generated file '%v' for writing",
+				fileId)
+		}
+		defer fp.Close()
+		// os.Create's mode depends on the umask, so we set it explicitly; initializers and templates can then change it
+		if err := fp.Chmod(mountedFileMode); err != nil {
+			return "", nil, stacktrace.Propagate(err, "An error occurred setting the mode of generated file '%v'", fileId)
+		}
+		generatedFilesFps[fileId] = fp
+	}
+	for fileKey := range generatedFiles {
+		if _, found := generatedFilesRelativeFilepaths[fileKey]; !found {
+			return "", nil, stacktrace.NewError("The Kurtosis API didn't generate a file for generated file '%v'", fileKey)
+		}
+	}
+
+	// The initializer only gets the files it declared itself, not the ones rendered from templates
+	filesToMountFps := map[string]*os.File{}
+	for fileKey := range initializer.GetFilesToMount() {
+		if fp, found := generatedFilesFps[fileKey]; found {
+			filesToMountFps[fileKey] = fp
+		}
+	}
+
+	logrus.Trace("Initializing generated files...")
+	if err := initializer.InitializeMountedFiles(filesToMountFps); err != nil {
+		return "", nil, stacktrace.Propagate(err, "An error occurred initializing the generated files")
+	}
+	logrus.Trace("Successfully initialized generated files")
+
+	serviceIpAddr := registerServiceResp.IpAddr
+	// Snapshotted once so that the files, start command, and env vars all see the same network
+	referenceableServiceData := networkCtx.getReferenceableServiceData()
+	if len(fileTemplates) > 0 {
+		logrus.Trace("Rendering templated files...")
+		templateData := services.FileTemplateData{
+			ServiceID:          serviceId,
+			IPAddress:          serviceIpAddr,
+			GeneratedFilepaths: generatedFilesAbsoluteFilepathsOnService,
+			Services:           referenceableServiceData,
+		}
+		for fileKey, fileTemplate := range fileTemplates {
+			fp, found := generatedFilesFps[fileKey]
+			if !found {
+				return "", nil, stacktrace.NewError("The Kurtosis API didn't generate a file for file template '%v'", fileKey)
+			}
+			renderedContents := &bytes.Buffer{}
+			if err := fileTemplate.Render(renderedContents, templateData); err != nil {
+				return "", nil, stacktrace.Propagate(err, "An error occurred rendering file template '%v'", fileKey)
+			}
+			resolvedContents, err := resolveServiceReferences(renderedContents.String(), referenceableServiceData)
+			if err != nil {
+				return "", nil, stacktrace.Propagate(err, "An error occurred resolving the service references in file template '%v'", fileKey)
+			}
+			if _, err := fp.WriteString(resolvedContents); err != nil {
+				return "", nil, stacktrace.Propagate(err, "An error occurred writing rendered file template '%v'", fileKey)
+			}
+			if err := fp.Chmod(fileTemplate.GetMode()); err != nil {
+				return "", nil, stacktrace.Propagate(err, "An error occurred setting the mode of rendered file template '%v'", fileKey)
+			}
+		}
+		logrus.Trace("Successfully rendered templated files")
+	}
+
+
+	logrus.Tracef("Creating files artifact mount dirpaths maps...")
+	artifactUrlToMountDirpath := map[string]string{}
+	localArtifactArchiveToMountDirpath := map[string]string{}
+	for filesArtifactId, mountDirpath := range initializer.GetFilesArtifactMountpoints() {
+		if artifactUrl, found := networkCtx.filesArtifactUrls[filesArtifactId]; found {
+			artifactUrlToMountDirpath[string(artifactUrl)] = mountDirpath
+			continue
+		}
+		if _, found := networkCtx.localFilesArtifactPaths[filesArtifactId]; found {
+			archiveRelativeFilepath, err := networkCtx.getOrPackLocalFilesArtifact(filesArtifactId)
+			if err != nil {
+				return "", nil, stacktrace.Propagate(err, "An error occurred packing local files artifact '%v'", filesArtifactId)
+			}
+			localArtifactArchiveToMountDirpath[archiveRelativeFilepath] = mountDirpath
+			continue
+		}
+		return "", nil, stacktrace.NewError(
+			"Service requested file artifact '%v', but the network " +
+				"context doesn't have a URL or local path for that file artifact; this is a bug with Kurtosis itself",
+			filesArtifactId)
+	}
+	logrus.Tracef("Successfully created files artifact mount dirpaths maps")
+
+	sharedVolumeMountDirpaths, err := networkCtx.getSharedVolumeMountDirpaths(services.GetInitializerSharedVolumeMountpoints(initializer))
+	if err != nil {
+		return "", nil, stacktrace.Propagate(err, "An error occurred creating the shared volumes mounted by the container")
+	}
+
+	logrus.Tracef("Creating start command for service...")
+	unresolvedStartCmdArgs, err := initializer.GetStartCommand(generatedFilesAbsoluteFilepathsOnService, serviceIpAddr)
+	if err != nil {
+		return "", nil, stacktrace.Propagate(err, "Failed to create start command")
+	}
+	// Nil means the image's command should be used, so must be preserved
+	var startCmdArgs []string
+	if unresolvedStartCmdArgs != nil {
+		startCmdArgs = []string{}
+		for idx, arg := range unresolvedStartCmdArgs {
+			resolvedArg, err := resolveServiceReferences(arg, referenceableServiceData)
+			if err != nil {
+				return "", nil, stacktrace.Propagate(err, "An error occurred resolving the service references in start command argument %v", idx)
+			}
+			startCmdArgs = append(startCmdArgs, resolvedArg)
+		}
+	}
+	logrus.Tracef("Successfully created start command for service")
+
+	resolvedEnvVars := map[string]string{}
+	for key, value := range envVars {
+		resolvedValue, err := resolveServiceReferences(value, referenceableServiceData)
+		if err != nil {
+			return "", nil, stacktrace.Propagate(err, "An error occurred resolving the service references in env var '%v'", key)
+		}
+		resolvedEnvVars[key] = resolvedValue
+	}
+
+	usedPortSpecs := map[string]bool{}
+	for _, port := range usedPorts {
+		usedPortSpecs[port.String()] = true
+	}
+
+	logrus.Tracef("Starting new service with Kurtosis API...")
+	startServiceArgs := &bindings.StartServiceArgs{
+		ServiceId:                   string(serviceId),
+		DockerImage:                 initializer.GetDockerImage(),
+		UsedPorts:                   usedPortSpecs,
+		StartCmdArgs:                startCmdArgs,
+		DockerEnvVars:               resolvedEnvVars,
+		SuiteExecutionVolMntDirpath: initializer.GetTestVolumeMountpoint(),
+		FilesArtifactMountDirpaths:  artifactUrlToMountDirpath,
+		ResourceLimits:              newResourceLimitsBinding(resourceLimits),
+		SharedVolumeMountDirpaths:   sharedVolumeMountDirpaths,
+		LocalFilesArtifactMountDirpaths: localArtifactArchiveToMountDirpath,
+	}
+	if _, err := networkCtx.client.StartService(ctx, startServiceArgs); err != nil {
+		return "", nil, stacktrace.Propagate(err, "An error occurred starting the service with the Kurtosis API")
+	}
+	logrus.Tracef("Successfully started service with Kurtosis API")
+
+	return serviceIpAddr, startCmdArgs, nil
+}
+
+/*
+Removes a container that was registered but couldn't be started, along with any files generated for it, so that its
+	ID can be reused. Every cleanup step is attempted even if earlier ones fail.
+ */
+func (networkCtx *NetworkContext) rollBackRegistration(serviceId services.ServiceID, generatedFilesRelativeFilepaths map[string]string) error {
+	logrus.Debugf("Rolling back registration of service '%v'...", serviceId)
+	errorStrs := []string{}
+
+	removeArgs := &bindings.RemoveServiceArgs{
+		ServiceId:                   string(serviceId),
+		ContainerStopTimeoutSeconds: 0,
+	}
+	if _, err := networkCtx.client.RemoveService(context.Background(), removeArgs); err != nil {
+		errorStrs = append(errorStrs, stacktrace.Propagate(err, "An error occurred removing the service from the Kurtosis API").Error())
+	}
+
+	for fileId, relativeFilepath := range generatedFilesRelativeFilepaths {
+		absoluteFilepathOnTestsuite := path.Join(test_suite_container_mountpoints.SuiteExVolMountpoint, relativeFilepath)
+		// Generated files can be directories, so their contents must be removed too
+		if err := os.RemoveAll(absoluteFilepathOnTestsuite); err != nil {
+			errorStrs = append(errorStrs, stacktrace.Propagate(err, "An error occurred removing generated file '%v'", fileId).Error())
+		}
+	}
+
+	if len(errorStrs) > 0 {
+		return stacktrace.NewError("Errors occurred rolling back the registration of service '%v':\n%v", serviceId, strings.Join(errorStrs, "\n"))
+	}
+	logrus.Debugf("Successfully rolled back registration of service '%v'", serviceId)
 	return nil
 }
+
+/*
+Reserves the given ID for a service or job that's about to be started, returning an error if the ID is already in
+	use or reserved
+ */
+func (networkCtx *NetworkContext) reserveServiceId(serviceId services.ServiceID) error {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	if _, found := networkCtx.services[serviceId]; found {
+		return stacktrace.NewError("A service with ID '%v' already exists in the network", serviceId)
+	}
+	if networkCtx.pendingServiceIds[serviceId] {
+		return stacktrace.NewError("A service or job with ID '%v' is already being started", serviceId)
+	}
+	networkCtx.pendingServiceIds[serviceId] = true
+	return nil
+}
+
+func (networkCtx *NetworkContext) releaseServiceIdReservation(serviceId services.ServiceID) {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	delete(networkCtx.pendingServiceIds, serviceId)
+}
+
+/*
+Waits for the given job to exit, and then gathers its exit code, logs, and output files
+ */
+func (networkCtx *NetworkContext) collectJobResult(
+		jobId services.ServiceID,
+		initializer services.DockerJobInitializer,
+		timeout time.Duration) (*services.JobResult, error) {
+	ctx := context.Background()
+
+	logrus.Debugf("Waiting up to %v for job '%v' to exit...", timeout, jobId)
+	waitArgs := &bindings.WaitForServiceExitArgs{
+		ServiceId:      string(jobId),
+		// Rounded up so that a sub-second timeout doesn't become 0
+		TimeoutSeconds: uint64(math.Ceil(timeout.Seconds())),
+	}
+	waitResp, err := networkCtx.client.WaitForServiceExit(ctx, waitArgs)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred waiting for job '%v' to exit", jobId)
+	}
+	exitCode := waitResp.ExitCode
+	logrus.Debugf("Job '%v' exited with exit code %v", jobId, exitCode)
+
+	logsResp, err := networkCtx.client.GetServiceLogs(ctx, &bindings.GetServiceLogsArgs{ServiceId: string(jobId)})
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the logs of job '%v'", jobId)
+	}
+
+	outputFilepaths := map[string]string{}
+	for outputKey, containerPath := range initializer.GetOutputFilepaths() {
+		outputFilepath, err := networkCtx.copyFromContainer(jobId, containerPath)
+		if err != nil {
+			return nil, stacktrace.Propagate(
+				err,
+				"An error occurred copying output '%v' at path '%v' out of job '%v'",
+				outputKey,
+				containerPath,
+				jobId)
+		}
+		outputFilepaths[outputKey] = outputFilepath
+	}
+
+	result := &services.JobResult{
+		ExitCode:        exitCode,
+		Logs:            logsResp.Logs,
+		OutputFilepaths: outputFilepaths,
+	}
+	return result, nil
+}
+
+/*
+Copies the given path out of the given container and onto the suite execution volume
+
+Returns:
+	The absolute path *on the testsuite container* where the copied file or directory can be found
+ */
+func (networkCtx *NetworkContext) copyFromContainer(serviceId services.ServiceID, containerPath string) (string, error) {
+	// The copied entry is named after the last element of the path, so e.g. a trailing slash must be stripped first
+	containerPath = path.Clean(containerPath)
+	if containerPath == "/" {
+		return "", stacktrace.NewError("Can't copy the root of the container of service '%v'; a path beneath it must be given", serviceId)
+	}
+
+	args := &bindings.CopyFromServiceArgs{
+		ServiceId:     string(serviceId),
+		ContainerPath: containerPath,
+	}
+	resp, err := networkCtx.client.CopyFromService(context.Background(), args)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred copying path '%v' out of service '%v'", containerPath, serviceId)
+	}
+
+	// The archive contains a single entry named after the last element of the copied path, so we extract it into
+	//  a directory next to the archive
+	archiveFilepath := path.Join(test_suite_container_mountpoints.SuiteExVolMountpoint, resp.ArchiveRelativeFilepath)
+	extractionDirpath := strings.TrimSuffix(archiveFilepath, path.Ext(archiveFilepath))
+	if extractionDirpath == archiveFilepath {
+		extractionDirpath = archiveFilepath + extractedArchiveDirSuffix
+	}
+	if err := extractTarArchive(archiveFilepath, extractionDirpath); err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred extracting archive '%v'", archiveFilepath)
+	}
+	if err := os.Remove(archiveFilepath); err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred removing archive '%v' after extracting it", archiveFilepath)
+	}
+
+	return path.Join(extractionDirpath, path.Base(containerPath)), nil
+}
+
+// Gets the IPs & ports of the services currently in the network, for rendering file templates and resolving service
+//  references
+func (networkCtx *NetworkContext) getReferenceableServiceData() map[services.ServiceID]services.FileTemplateServiceData {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	result := map[services.ServiceID]services.FileTemplateServiceData{}
+	for serviceId, serviceInfo := range networkCtx.serviceInfos {
+		result[serviceId] = services.FileTemplateServiceData{
+			IPAddress: serviceInfo.IPAddress,
+			UsedPorts: append([]services.Port{}, serviceInfo.UsedPorts...),
+		}
+	}
+	return result
+}
+
+// Gets a snapshot of the availability checkers of all the services currently in the network
+func (networkCtx *NetworkContext) getAvailabilityCheckers() map[services.ServiceID]services.AvailabilityChecker {
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	result := map[services.ServiceID]services.AvailabilityChecker{}
+	for serviceId, checker := range networkCtx.serviceAvailabilityCheckers {
+		result[serviceId] = checker
+	}
+	return result
+}
+
+func newResourceLimitsBinding(resourceLimits services.ResourceLimits) *bindings.ResourceLimits {
+	return &bindings.ResourceLimits{
+		CpuMillicores:    resourceLimits.CpuMillicores,
+		MemoryLimitBytes: resourceLimits.MemoryLimitBytes,
+		PidsLimit:        resourceLimits.PidsLimit,
+	}
+}
@@ -0,0 +1,892 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"github.com/kurtosis-tech/kurtosis-go/lib/core_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/kurtosis-tech/kurtosis-go/lib/test_suite_docker_consts/test_suite_container_mountpoints"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testStartServiceDelay = 200 * time.Millisecond
+
+	// How long a StartService call waits for the others to be in flight before concluding they weren't started in parallel
+	testParallelStartTimeout = 5 * time.Second
+
+	failingStartServiceId services.ServiceID = "failing-service"
+
+	testGeneratedFileId = "config"
+
+	testJobId services.ServiceID = "job"
+	testJobLogs = "job logs"
+)
+
+// Only the endpoints used for starting & removing services are implemented; calling any other will panic
+type startServicesTestClient struct {
+	bindings.TestExecutionServiceClient
+}
+
+func (client startServicesTestClient) RegisterService(ctx context.Context, in *bindings.RegisterServiceArgs, opts ...grpc.CallOption) (*bindings.RegisterServiceResponse, error) {
+	return &bindings.RegisterServiceResponse{
+		GeneratedFilesRelativeFilepaths: map[string]string{},
+		IpAddr:                          "1.2.3.4",
+	}, nil
+}
+
+func (client startServicesTestClient) StartService(ctx context.Context, in *bindings.StartServiceArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	time.Sleep(testStartServiceDelay)
+	if in.ServiceId == string(failingStartServiceId) {
+		return nil, stacktrace.NewError("Test start error")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (client startServicesTestClient) RemoveService(ctx context.Context, in *bindings.RemoveServiceArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+/*
+A client whose StartService calls block until all the expected number of calls are in flight at once, so that starting
+	services serially fails rather than deadlocking
+ */
+type parallelStartTestClient struct {
+	startServicesTestClient
+	inFlightStarts *sync.WaitGroup
+}
+
+func newParallelStartTestClient(numExpectedStarts int) *parallelStartTestClient {
+	inFlightStarts := &sync.WaitGroup{}
+	inFlightStarts.Add(numExpectedStarts)
+	return &parallelStartTestClient{inFlightStarts: inFlightStarts}
+}
+
+func (client *parallelStartTestClient) StartService(ctx context.Context, in *bindings.StartServiceArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	client.inFlightStarts.Done()
+	allInFlightChan := make(chan struct{})
+	go func() {
+		client.inFlightStarts.Wait()
+		close(allInFlightChan)
+	}()
+
+	select {
+	case <- allInFlightChan:
+		return &emptypb.Empty{}, nil
+	case <- time.After(testParallelStartTimeout):
+		return nil, stacktrace.NewError("Service '%v' was started, but not all services were being started at the same time", in.ServiceId)
+	}
+}
+
+func TestAddServicesStartsInParallel(t *testing.T) {
+	initializers := map[services.ServiceID]services.DockerContainerInitializer{
+		service1: services.NewMockDockerContainerInitializer(),
+		service2: services.NewMockDockerContainerInitializer(),
+		"service3": services.NewMockDockerContainerInitializer(),
+	}
+	client := newParallelStartTestClient(len(initializers))
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	addedServices, availabilityCheckers, err := networkCtx.AddServices(initializers)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the services"))
+	}
+	assert.Equal(t, len(initializers), len(addedServices))
+	assert.Equal(t, len(initializers), len(availabilityCheckers))
+	assert.Equal(t, len(initializers), len(networkCtx.services))
+	assert.Equal(t, 0, len(networkCtx.pendingServiceIds))
+}
+
+func TestAddServicesReportsPartialFailure(t *testing.T) {
+	networkCtx := NewNetworkContext(startServicesTestClient{}, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	initializers := map[services.ServiceID]services.DockerContainerInitializer{
+		service1: services.NewMockDockerContainerInitializer(),
+		failingStartServiceId: services.NewMockDockerContainerInitializer(),
+	}
+
+	addedServices, _, err := networkCtx.AddServices(initializers)
+	assert.Error(t, err)
+	addErr, ok := stacktrace.RootCause(err).(*AddServicesError)
+	if !ok {
+		t.Fatal(stacktrace.NewError("Expected an AddServicesError but got: %v", err))
+	}
+	assert.Equal(t, 1, len(addErr.Failures))
+	_, found := addErr.Failures[failingStartServiceId]
+	assert.True(t, found)
+
+	_, found = addedServices[service1]
+	assert.True(t, found)
+	_, found = networkCtx.services[failingStartServiceId]
+	assert.False(t, found)
+}
+
+func TestAddServiceRejectsDuplicateIds(t *testing.T) {
+	networkCtx := NewNetworkContext(startServicesTestClient{}, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	// Two concurrent adds of the same ID must not both start a container
+	resultChan := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer())
+			resultChan <- err
+		}()
+	}
+	numErrs := 0
+	for i := 0; i < 2; i++ {
+		if err := <- resultChan; err != nil {
+			numErrs++
+		}
+	}
+	assert.Equal(t, 1, numErrs)
+
+	// Adding an ID that's already in the network should fail too
+	_, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer())
+	assert.Error(t, err)
+}
+
+// Records the arguments of the last service registered & started, and of the last resource limits update
+type argsRecordingTestClient struct {
+	startServicesTestClient
+
+	lastRegisterServiceArgs *bindings.RegisterServiceArgs
+	lastStartServiceArgs *bindings.StartServiceArgs
+	lastUpdateServiceResourceLimitsArgs *bindings.UpdateServiceResourceLimitsArgs
+}
+
+func (client *argsRecordingTestClient) RegisterService(ctx context.Context, in *bindings.RegisterServiceArgs, opts ...grpc.CallOption) (*bindings.RegisterServiceResponse, error) {
+	client.lastRegisterServiceArgs = in
+	return client.startServicesTestClient.RegisterService(ctx, in, opts...)
+}
+
+func (client *argsRecordingTestClient) StartService(ctx context.Context, in *bindings.StartServiceArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	client.lastStartServiceArgs = in
+	return &emptypb.Empty{}, nil
+}
+
+func (client *argsRecordingTestClient) UpdateServiceResourceLimits(ctx context.Context, in *bindings.UpdateServiceResourceLimitsArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	client.lastUpdateServiceResourceLimitsArgs = in
+	return &emptypb.Empty{}, nil
+}
+
+type neverAvailableCheck struct{}
+
+func (check neverAvailableCheck) Check(ctx context.Context, service services.Service, containerAccessor services.ContainerAccessor) error {
+	return stacktrace.NewError("Test service is never available")
+}
+
+func TestAddServiceOptions(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	envVars := map[string]string{"LOG_LEVEL": "debug"}
+	labels := map[string]string{"role": "validator"}
+	resourceLimits := services.ResourceLimits{CpuMillicores: 500}
+	_, _, err := networkCtx.AddService(
+		service1,
+		services.NewMockDockerContainerInitializer(),
+		WithPartition(partition1),
+		WithEnvVars(envVars),
+		WithLabels(labels),
+		WithResourceLimits(resourceLimits),
+		WithStartupWait(time.Second, services.NewConstantBackoff(10 * time.Millisecond)))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+
+	assert.Equal(t, string(partition1), client.lastRegisterServiceArgs.PartitionId)
+	assert.Equal(t, envVars, client.lastStartServiceArgs.DockerEnvVars)
+	assert.Equal(t, uint64(500), client.lastStartServiceArgs.ResourceLimits.CpuMillicores)
+
+	actualLabels, err := networkCtx.GetServiceLabels(service1)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the service labels"))
+	}
+	assert.Equal(t, labels, actualLabels)
+}
+
+func TestAddServiceDefaultOptions(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+
+	assert.Equal(t, string(defaultPartitionId), client.lastRegisterServiceArgs.PartitionId)
+	assert.Equal(t, 0, len(client.lastStartServiceArgs.DockerEnvVars))
+	labels, err := networkCtx.GetServiceLabels(service1)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the service labels"))
+	}
+	assert.Equal(t, 0, len(labels))
+}
+
+func TestAddServiceStartupWaitTimesOut(t *testing.T) {
+	networkCtx := NewNetworkContext(&argsRecordingTestClient{}, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.AvailabilityCheck = neverAvailableCheck{}
+
+	_, _, err := networkCtx.AddService(
+		service1,
+		initializer,
+		WithStartupWait(50 * time.Millisecond, services.NewConstantBackoff(10 * time.Millisecond)))
+	assert.Error(t, err)
+
+	// The service should have been rolled back, so the ID can be reused
+	_, found := networkCtx.GetServiceIDs()[service1]
+	assert.False(t, found)
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred re-adding the service after its rollback"))
+	}
+}
+
+func TestGetServicesByLabels(t *testing.T) {
+	networkCtx := NewNetworkContext(&argsRecordingTestClient{}, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	serviceLabels := map[services.ServiceID]map[string]string{
+		"validator-a": {"role": "validator", "zone": "a"},
+		"validator-b": {"role": "validator", "zone": "b"},
+		"observer-a":  {"role": "observer", "zone": "a"},
+	}
+	for serviceId, labels := range serviceLabels {
+		if _, _, err := networkCtx.AddService(serviceId, services.NewMockDockerContainerInitializer(), WithLabels(labels)); err != nil {
+			t.Fatal(stacktrace.Propagate(err, "An error occurred adding service '%v'", serviceId))
+		}
+	}
+
+	validatorSelector := NewLabelSelector(map[string]string{"role": "validator"})
+	assert.Equal(t, []services.ServiceID{"validator-a", "validator-b"}, networkCtx.GetServiceIDsByLabels(validatorSelector))
+
+	zoneASelector := NewLabelSelector(map[string]string{"zone": "a"})
+	zoneAServices := networkCtx.GetServicesByLabels(zoneASelector)
+	assert.Equal(t, 2, len(zoneAServices))
+	assert.Equal(t, services.ServiceID("observer-a"), zoneAServices["observer-a"].GetServiceID())
+
+	if err := networkCtx.RemoveService("validator-a", 0); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred removing the service"))
+	}
+	assert.Equal(t, []services.ServiceID{"validator-b"}, networkCtx.GetServiceIDsByLabels(validatorSelector))
+}
+
+func TestServiceInfoAndNetworkState(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer(), WithLabels(map[string]string{"role": "validator"})); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding service '%v'", service1))
+	}
+	if _, _, err := networkCtx.AddService(service2, services.NewMockDockerContainerInitializer(), WithPartition(partition2)); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding service '%v'", service2))
+	}
+
+	serviceInfo, err := networkCtx.GetServiceInfo(service1)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the service info"))
+	}
+	assert.Equal(t, service1, serviceInfo.ServiceID)
+	assert.Equal(t, "1.2.3.4", serviceInfo.IPAddress)
+	assert.Equal(t, services.NewMockDockerContainerInitializer().GetDockerImage(), serviceInfo.DockerImage)
+	assert.Equal(t, services.NewMockDockerContainerInitializer().GetUsedPorts(), serviceInfo.UsedPorts)
+	assert.Equal(t, defaultPartitionId, serviceInfo.PartitionID)
+	assert.Equal(t, "validator", serviceInfo.Labels["role"])
+	assert.False(t, serviceInfo.StartTime.IsZero())
+
+	// Modifying the returned info shouldn't modify the network context's state
+	serviceInfo.Labels["role"] = "observer"
+	labels, err := networkCtx.GetServiceLabels(service1)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the service labels"))
+	}
+	assert.Equal(t, "validator", labels["role"])
+
+	assert.Equal(t, 2, len(networkCtx.GetAllServiceInfo()))
+
+	stateBytes, err := networkCtx.DumpNetworkStateJson()
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred dumping the network state"))
+	}
+	var state NetworkState
+	if err := json.Unmarshal(stateBytes, &state); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred deserializing the network state"))
+	}
+	assert.Equal(t, 2, len(state.Services))
+	assert.Equal(t, []services.ServiceID{service1}, state.Partitions[defaultPartitionId])
+	assert.Equal(t, []services.ServiceID{service2}, state.Partitions[partition2])
+}
+
+// Generates files into a temporary directory, and records the services that were removed
+type rollbackTestClient struct {
+	startServicesTestClient
+
+	generatedFilesRelativeFilepaths map[string]string
+	removeServiceErr error
+	removedServiceIds []services.ServiceID
+}
+
+func (client *rollbackTestClient) RegisterService(ctx context.Context, in *bindings.RegisterServiceArgs, opts ...grpc.CallOption) (*bindings.RegisterServiceResponse, error) {
+	return &bindings.RegisterServiceResponse{
+		GeneratedFilesRelativeFilepaths: client.generatedFilesRelativeFilepaths,
+		IpAddr:                          "1.2.3.4",
+	}, nil
+}
+
+func (client *rollbackTestClient) RemoveService(ctx context.Context, in *bindings.RemoveServiceArgs, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	client.removedServiceIds = append(client.removedServiceIds, services.ServiceID(in.ServiceId))
+	if client.removeServiceErr != nil {
+		return nil, client.removeServiceErr
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// Writes part of its mounted file before failing
+func newFailingFilesTestInitializer() *services.MockDockerContainerInitializer {
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.FilesToMount = map[string]bool{testGeneratedFileId: true}
+	initializer.InitializeMountedFilesFunc = func(mountedFiles map[string]*os.File) error {
+		if _, err := mountedFiles[testGeneratedFileId].WriteString("half-written"); err != nil {
+			return stacktrace.Propagate(err, "An error occurred writing the generated file")
+		}
+		return stacktrace.NewError("Test file initialization error")
+	}
+	return initializer
+}
+
+func TestAddServiceRollsBackFailedFileInitialization(t *testing.T) {
+	client, generatedFilepath := newRollbackTestClient(t)
+	defer os.RemoveAll(path.Dir(generatedFilepath))
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	_, _, err := networkCtx.AddService(service1, newFailingFilesTestInitializer())
+	assert.Error(t, err)
+
+	assert.Equal(t, []services.ServiceID{service1}, client.removedServiceIds)
+	_, err = os.Stat(generatedFilepath)
+	assert.True(t, os.IsNotExist(err), "Expected the generated file to have been removed")
+
+	// The service ID shouldn't still be taken, so retrying should work
+	client.generatedFilesRelativeFilepaths = map[string]string{}
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred retrying adding the service"))
+	}
+}
+
+func TestAddServiceRollsBackFailedStart(t *testing.T) {
+	client, generatedFilepath := newRollbackTestClient(t)
+	defer os.RemoveAll(path.Dir(generatedFilepath))
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	_, _, err := networkCtx.AddService(failingStartServiceId, services.NewMockDockerContainerInitializer())
+	assert.Error(t, err)
+
+	assert.Equal(t, []services.ServiceID{failingStartServiceId}, client.removedServiceIds)
+	_, err = os.Stat(generatedFilepath)
+	assert.True(t, os.IsNotExist(err), "Expected the generated file to have been removed")
+	_, found := networkCtx.GetServiceIDs()[failingStartServiceId]
+	assert.False(t, found)
+}
+
+func TestAddServiceReportsRollbackFailure(t *testing.T) {
+	client, generatedFilepath := newRollbackTestClient(t)
+	defer os.RemoveAll(path.Dir(generatedFilepath))
+	client.removeServiceErr = stacktrace.NewError("Test remove error")
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	_, _, err := networkCtx.AddService(failingStartServiceId, services.NewMockDockerContainerInitializer())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Test start error")
+	assert.Contains(t, err.Error(), "Test remove error")
+
+	// The rest of the cleanup should still have happened
+	_, err = os.Stat(generatedFilepath)
+	assert.True(t, os.IsNotExist(err), "Expected the generated file to have been removed")
+}
+
+func TestAddServiceRendersFileTemplates(t *testing.T) {
+	client, generatedFilepath := newRollbackTestClient(t)
+	defer os.RemoveAll(path.Dir(generatedFilepath))
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	client.generatedFilesRelativeFilepaths = map[string]string{}
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service referenced by the template"))
+	}
+
+	fileTemplate, err := services.NewFileTemplate(
+		`{{.ServiceID}} {{.IPAddress}} {{serviceIP "service1"}} {{index (servicePorts "service1") 0}} {{.Values.level}}`,
+		map[string]interface{}{"level": "debug"},
+		0600)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the file template"))
+	}
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.FileTemplates = map[string]*services.FileTemplate{testGeneratedFileId: fileTemplate}
+	client.generatedFilesRelativeFilepaths = map[string]string{testGeneratedFileId: mustGetSuiteExVolRelativeFilepath(t, generatedFilepath)}
+	if _, _, err := networkCtx.AddService(service2, initializer); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service with the templated file"))
+	}
+
+	contents, err := ioutil.ReadFile(generatedFilepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading the rendered file"))
+	}
+	assert.Equal(t, "service2 1.2.3.4 1.2.3.4 1000/tcp debug", string(contents))
+	assertFileMode(t, generatedFilepath, 0600)
+}
+
+func TestAddServiceLetsInitializerSetMountedFileMode(t *testing.T) {
+	client, generatedFilepath := newRollbackTestClient(t)
+	defer os.RemoveAll(path.Dir(generatedFilepath))
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.FilesToMount = map[string]bool{testGeneratedFileId: true}
+	initializer.InitializeMountedFilesFunc = func(mountedFiles map[string]*os.File) error {
+		return mountedFiles[testGeneratedFileId].Chmod(0755)
+	}
+	client.generatedFilesRelativeFilepaths = map[string]string{testGeneratedFileId: mustGetSuiteExVolRelativeFilepath(t, generatedFilepath)}
+	if _, _, err := networkCtx.AddService(service1, initializer); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+	assertFileMode(t, generatedFilepath, 0755)
+}
+
+func TestAddServiceRollsBackFailedFileTemplate(t *testing.T) {
+	client, generatedFilepath := newRollbackTestClient(t)
+	defer os.RemoveAll(path.Dir(generatedFilepath))
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	fileTemplate, err := services.NewFileTemplate(`{{serviceIP "nonexistent"}}`, nil, 0)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the file template"))
+	}
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.FileTemplates = map[string]*services.FileTemplate{testGeneratedFileId: fileTemplate}
+	_, _, err = networkCtx.AddService(service1, initializer)
+	assert.Error(t, err)
+	assert.Equal(t, []services.ServiceID{service1}, client.removedServiceIds)
+}
+
+func TestAddServiceRejectsOverlappingFileTemplateKeys(t *testing.T) {
+	networkCtx := NewNetworkContext(&argsRecordingTestClient{}, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	fileTemplate, err := services.NewFileTemplate("contents", nil, 0)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the file template"))
+	}
+	// Declares the same key as both a file to mount and a file template
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.FilesToMount = map[string]bool{testGeneratedFileId: true}
+	initializer.FileTemplates = map[string]*services.FileTemplate{testGeneratedFileId: fileTemplate}
+	_, _, err = networkCtx.AddService(service1, initializer)
+	assert.Error(t, err)
+	// Nothing should have been registered
+	assert.Nil(t, networkCtx.client.(*argsRecordingTestClient).lastRegisterServiceArgs)
+}
+
+func newGeneratedDirectoryTestInitializer() *services.MockDockerContainerInitializer {
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.GeneratedFiles = map[string]*services.GeneratedFile{
+		testGeneratedFileId: services.NewGeneratedDirectory(map[string]*services.GeneratedFile{
+			"run.sh": services.NewGeneratedFileFromBytes([]byte("#!/bin/sh"), 0755),
+		}, 0),
+	}
+	return initializer
+}
+
+func TestAddServiceGeneratesDirectories(t *testing.T) {
+	client, generatedFilepath := newRollbackTestClient(t)
+	defer os.RemoveAll(path.Dir(generatedFilepath))
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	if _, _, err := networkCtx.AddService(service1, newGeneratedDirectoryTestInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+	scriptInfo, err := os.Stat(path.Join(generatedFilepath, "run.sh"))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting info about the generated script"))
+	}
+	assert.Equal(t, os.FileMode(0755), scriptInfo.Mode().Perm())
+}
+
+func TestAddServiceRollsBackGeneratedDirectory(t *testing.T) {
+	client, generatedFilepath := newRollbackTestClient(t)
+	defer os.RemoveAll(path.Dir(generatedFilepath))
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	_, _, err := networkCtx.AddService(failingStartServiceId, newGeneratedDirectoryTestInitializer())
+	assert.Error(t, err)
+	_, err = os.Stat(generatedFilepath)
+	assert.True(t, os.IsNotExist(err), "Expected the generated directory to have been removed")
+}
+
+func TestAddServiceRejectsInvalidGeneratedFiles(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.GeneratedFiles = map[string]*services.GeneratedFile{
+		testGeneratedFileId: services.NewGeneratedFileFromTestsuitePath("relative/path", 0),
+	}
+
+	_, _, err := networkCtx.AddService(service1, initializer)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Testsuite path 'relative/path' isn't an absolute path")
+	assert.Nil(t, client.lastRegisterServiceArgs)
+}
+
+// Returns a client that generates a file in a new temporary directory, and the absolute path of the file
+func newRollbackTestClient(t *testing.T) (*rollbackTestClient, string) {
+	tempDirpath, err := ioutil.TempDir("", "rollback-test")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the temporary directory"))
+	}
+	generatedFilepath := path.Join(tempDirpath, "generated-file")
+
+	client := &rollbackTestClient{
+		generatedFilesRelativeFilepaths: map[string]string{testGeneratedFileId: mustGetSuiteExVolRelativeFilepath(t, generatedFilepath)},
+		removedServiceIds:               []services.ServiceID{},
+	}
+	return client, generatedFilepath
+}
+
+// Generated files are returned by the API relative to the suite execution volume, so we point back at the given file
+func mustGetSuiteExVolRelativeFilepath(t *testing.T, absoluteFilepath string) string {
+	relativeFilepath, err := filepath.Rel(test_suite_container_mountpoints.SuiteExVolMountpoint, absoluteFilepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the generated filepath relative to the suite execution volume"))
+	}
+	return relativeFilepath
+}
+
+func TestAddServiceFromSpec(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	specs, err := services.ParseYamlServiceSpecs([]byte(`
+services:
+  nginx:
+    image: nginx
+    ports:
+      - name: http
+        number: 80
+    env:
+      FROM_SPEC: spec
+      OVERRIDDEN: spec
+    command: ["nginx", "-g", "{{.IPAddress}}"]
+    testVolumeMountpoint: /test-volume
+`))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred parsing the specs"))
+	}
+
+	service, _, err := networkCtx.AddServiceFromSpec(service1, specs, "nginx", WithEnvVars(map[string]string{"OVERRIDDEN": "option"}))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service from the spec"))
+	}
+	assert.Equal(t, service1, service.GetServiceID())
+	assert.Equal(t, "nginx", client.lastStartServiceArgs.DockerImage)
+	assert.Equal(t, []string{"nginx", "-g", "1.2.3.4"}, client.lastStartServiceArgs.StartCmdArgs)
+	assert.Equal(t, map[string]string{"FROM_SPEC": "spec", "OVERRIDDEN": "option"}, client.lastStartServiceArgs.DockerEnvVars)
+
+	_, _, err = networkCtx.AddServiceFromSpec(service2, specs, "nonexistent")
+	assert.Error(t, err)
+}
+
+func TestGetServicePort(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+	// The API still receives ports in Docker port specification syntax
+	assert.Equal(t, map[string]bool{"1000/tcp": true}, client.lastStartServiceArgs.UsedPorts)
+
+	port, err := networkCtx.GetServicePort(service1, services.MockServicePortName)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the service's port"))
+	}
+	assert.Equal(t, services.Port{Number: services.MockServicePort, Protocol: services.TCP, Name: services.MockServicePortName}, port)
+
+	_, err = networkCtx.GetServicePort(service1, "nonexistent")
+	assert.Error(t, err)
+	_, err = networkCtx.GetServicePort(service2, services.MockServicePortName)
+	assert.Error(t, err)
+}
+
+func TestAddServiceResolvesServiceReferences(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the referenced service"))
+	}
+
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.StartCommand = []string{"./client.bin", "--server=${service:service1:ip}:${service:service1:port:mock}"}
+	envVars := map[string]string{"SERVER_IP": "${service:service1:ip}"}
+	if _, _, err := networkCtx.AddService(service2, initializer, WithEnvVars(envVars)); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the referencing service"))
+	}
+	assert.Equal(t, []string{"./client.bin", "--server=1.2.3.4:1000"}, client.lastStartServiceArgs.StartCmdArgs)
+	assert.Equal(t, map[string]string{"SERVER_IP": "1.2.3.4"}, client.lastStartServiceArgs.DockerEnvVars)
+	// The initializer's own command mustn't be modified
+	assert.Equal(t, "--server=${service:service1:ip}:${service:service1:port:mock}", initializer.StartCommand[1])
+}
+
+func TestAddServiceRollsBackUnresolvableServiceReference(t *testing.T) {
+	client, generatedFilepath := newRollbackTestClient(t)
+	defer os.RemoveAll(path.Dir(generatedFilepath))
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.StartCommand = []string{"--server=${service:nonexistent:ip}"}
+	_, _, err := networkCtx.AddService(service1, initializer)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "nonexistent")
+	assert.Equal(t, []services.ServiceID{service1}, client.removedServiceIds)
+}
+
+func TestTypedServices(t *testing.T) {
+	networkCtx := NewNetworkContext(&argsRecordingTestClient{}, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	addedService, _, err := AddTypedService[*services.MockService](networkCtx, service1, services.NewMockTypedDockerContainerInitializer(), WithPartition(partition1))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the typed service"))
+	}
+	assert.Equal(t, service1, addedService.GetServiceID())
+
+	gottenService, err := GetTypedService[*services.MockService](networkCtx, service1)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the typed service"))
+	}
+	assert.Equal(t, addedService, gottenService)
+
+	// Requesting the wrong type should be an error rather than a panic
+	_, err = GetTypedService[*wrongTypeService](networkCtx, service1)
+	assert.Error(t, err)
+}
+
+type wrongTypeService struct {
+	services.MockService
+}
+
+/*
+Runs jobs that exit immediately, and copies every path out of them as a TAR archive containing a single file named
+	after the path
+ */
+type jobTestClient struct {
+	rollbackTestClient
+
+	t *testing.T
+	archivesDirpath string
+	exitCode int32
+	waitForServiceExitErr error
+
+	lastWaitForServiceExitArgs *bindings.WaitForServiceExitArgs
+	copiedContainerPaths []string
+}
+
+func newJobTestClient(t *testing.T) *jobTestClient {
+	return &jobTestClient{
+		rollbackTestClient: rollbackTestClient{
+			generatedFilesRelativeFilepaths: map[string]string{},
+			removedServiceIds:               []services.ServiceID{},
+		},
+		t:                    t,
+		archivesDirpath:      t.TempDir(),
+		copiedContainerPaths: []string{},
+	}
+}
+
+func (client *jobTestClient) WaitForServiceExit(ctx context.Context, in *bindings.WaitForServiceExitArgs, opts ...grpc.CallOption) (*bindings.WaitForServiceExitResponse, error) {
+	client.lastWaitForServiceExitArgs = in
+	if client.waitForServiceExitErr != nil {
+		return nil, client.waitForServiceExitErr
+	}
+	return &bindings.WaitForServiceExitResponse{ExitCode: client.exitCode}, nil
+}
+
+func (client *jobTestClient) GetServiceLogs(ctx context.Context, in *bindings.GetServiceLogsArgs, opts ...grpc.CallOption) (*bindings.GetServiceLogsResponse, error) {
+	return &bindings.GetServiceLogsResponse{Logs: []byte(testJobLogs)}, nil
+}
+
+func (client *jobTestClient) CopyFromService(ctx context.Context, in *bindings.CopyFromServiceArgs, opts ...grpc.CallOption) (*bindings.CopyFromServiceResponse, error) {
+	client.copiedContainerPaths = append(client.copiedContainerPaths, in.ContainerPath)
+	archiveFilepath := path.Join(client.archivesDirpath, strconv.Itoa(len(client.copiedContainerPaths)) + ".tar")
+	writeTestTarArchive(client.t, archiveFilepath, []*tar.Header{
+		{Name: path.Base(in.ContainerPath), Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(testFileContents))},
+	})
+	return &bindings.CopyFromServiceResponse{
+		ArchiveRelativeFilepath: mustGetSuiteExVolRelativeFilepath(client.t, archiveFilepath),
+	}, nil
+}
+
+type testJobInitializer struct {
+	*services.MockDockerContainerInitializer
+	outputFilepaths map[string]string
+}
+
+func newTestJobInitializer(outputFilepaths map[string]string) testJobInitializer {
+	return testJobInitializer{
+		MockDockerContainerInitializer: services.NewMockDockerContainerInitializer(),
+		outputFilepaths:                outputFilepaths,
+	}
+}
+
+func (initializer testJobInitializer) GetOutputFilepaths() map[string]string {
+	return initializer.outputFilepaths
+}
+
+func TestRunJob(t *testing.T) {
+	client := newJobTestClient(t)
+	client.exitCode = 3
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	initializer := newTestJobInitializer(map[string]string{"report": "/output/report.txt"})
+	result, err := networkCtx.RunJobInPartition(testJobId, partition1, initializer, 1500 * time.Millisecond)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred running the job"))
+	}
+
+	// A nonzero exit code is returned rather than being an error
+	assert.Equal(t, int32(3), result.ExitCode)
+	assert.Equal(t, testJobLogs, string(result.Logs))
+	assert.Equal(t, []string{"/output/report.txt"}, client.copiedContainerPaths)
+	reportContents, err := ioutil.ReadFile(result.OutputFilepaths["report"])
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading the job's report output"))
+	}
+	assert.Equal(t, testFileContents, string(reportContents))
+
+	// The timeout should be rounded up to whole seconds
+	assert.Equal(t, uint64(2), client.lastWaitForServiceExitArgs.TimeoutSeconds)
+
+	// The job should have been removed, and its ID should be free again
+	assert.Equal(t, []services.ServiceID{testJobId}, client.removedServiceIds)
+	assert.Equal(t, 0, len(networkCtx.GetServiceIDs()))
+	assert.Equal(t, 0, len(networkCtx.pendingServiceIds))
+}
+
+func TestRunJobRoundsUpSubSecondTimeout(t *testing.T) {
+	client := newJobTestClient(t)
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	if _, err := networkCtx.RunJob(testJobId, newTestJobInitializer(map[string]string{}), 100 * time.Millisecond); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred running the job"))
+	}
+	assert.Equal(t, uint64(1), client.lastWaitForServiceExitArgs.TimeoutSeconds)
+}
+
+func TestRunJobRemovesFailedJob(t *testing.T) {
+	client := newJobTestClient(t)
+	client.waitForServiceExitErr = stacktrace.NewError("Test wait error")
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	_, err := networkCtx.RunJob(testJobId, newTestJobInitializer(map[string]string{"report": "/output/report.txt"}), time.Second)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Test wait error")
+	assert.Equal(t, []services.ServiceID{testJobId}, client.removedServiceIds)
+	assert.Equal(t, 0, len(client.copiedContainerPaths))
+
+	// The job's ID should have been released, so a service can take it
+	if _, _, err := networkCtx.AddService(testJobId, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding a service with the failed job's ID"))
+	}
+}
+
+func TestRunJobReportsRemovalFailure(t *testing.T) {
+	client := newJobTestClient(t)
+	client.removeServiceErr = stacktrace.NewError("Test remove error")
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	_, err := networkCtx.RunJob(testJobId, newTestJobInitializer(map[string]string{}), time.Second)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Test remove error")
+}
+
+func TestRunJobRejectsInvalidArgs(t *testing.T) {
+	client := newJobTestClient(t)
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+
+	// Jobs share a namespace with services
+	_, err := networkCtx.RunJob(service1, newTestJobInitializer(map[string]string{}), time.Second)
+	assert.Error(t, err)
+
+	_, err = networkCtx.RunJob(testJobId, newTestJobInitializer(map[string]string{}), 0)
+	assert.Error(t, err)
+
+	// Neither job should have been started
+	assert.Nil(t, client.lastWaitForServiceExitArgs)
+	assert.Equal(t, 0, len(client.removedServiceIds))
+}
+
+func TestCopyFromService(t *testing.T) {
+	client := newJobTestClient(t)
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+
+	// A trailing slash or redundant elements shouldn't change which entry of the archive is returned
+	for _, containerPath := range []string{"/var/log/app.log", "/var/log/app.log/", "/var/./log//app.log"} {
+		copiedFilepath, err := networkCtx.CopyFromService(service1, containerPath)
+		if err != nil {
+			t.Fatal(stacktrace.Propagate(err, "An error occurred copying path '%v' out of the service", containerPath))
+		}
+		assert.Equal(t, "app.log", path.Base(copiedFilepath))
+		contents, err := ioutil.ReadFile(copiedFilepath)
+		if err != nil {
+			t.Fatal(stacktrace.Propagate(err, "An error occurred reading the file copied from path '%v'", containerPath))
+		}
+		assert.Equal(t, testFileContents, string(contents))
+	}
+	assert.Equal(t, []string{"/var/log/app.log", "/var/log/app.log", "/var/log/app.log"}, client.copiedContainerPaths)
+
+	// The archives should have been cleaned up after extraction
+	archiveFilepaths, err := filepath.Glob(path.Join(client.archivesDirpath, "*.tar"))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred listing the remaining archives"))
+	}
+	assert.Empty(t, archiveFilepaths)
+}
+
+func TestCopyFromServiceRejectsInvalidArgs(t *testing.T) {
+	client := newJobTestClient(t)
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+
+	_, err := networkCtx.CopyFromService(service1, "relative/path")
+	assert.Error(t, err)
+	_, err = networkCtx.CopyFromService(service1, "/")
+	assert.Error(t, err)
+	_, err = networkCtx.CopyFromService(service1, "//")
+	assert.Error(t, err)
+	_, err = networkCtx.CopyFromService(service2, "/var/log/app.log")
+	assert.Error(t, err)
+	assert.Equal(t, 0, len(client.copiedContainerPaths))
+}
+
+func assertFileMode(t *testing.T, filepath string, expectedMode os.FileMode) {
+	fileInfo, err := os.Stat(filepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting info for file '%v'", filepath))
+	}
+	assert.Equal(t, expectedMode, fileInfo.Mode().Perm())
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/core_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const (
+	testMemoryLimitBytes = 256 * 1024 * 1024
+)
+
+func TestAddServiceResourceLimits(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	// No limits means every field is left at 0, which the API container treats as unlimited
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service without limits"))
+	}
+	assertResourceLimitsBinding(t, services.ResourceLimits{}, client.lastStartServiceArgs.ResourceLimits)
+
+	// The initializer's limits are used by default, with its unset fields staying unlimited
+	limitedInitializer := services.NewMockDockerContainerInitializer()
+	limitedInitializer.ResourceLimits = services.ResourceLimits{CpuMillicores: 1500, MemoryLimitBytes: testMemoryLimitBytes}
+	if _, _, err := networkCtx.AddService(service2, limitedInitializer); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service with the initializer's limits"))
+	}
+	assertResourceLimitsBinding(
+		t,
+		services.ResourceLimits{CpuMillicores: 1500, MemoryLimitBytes: testMemoryLimitBytes, PidsLimit: 0},
+		client.lastStartServiceArgs.ResourceLimits)
+
+	// WithResourceLimits replaces the initializer's limits entirely rather than merging with them
+	if _, _, err := networkCtx.AddService("service3", limitedInitializer, WithResourceLimits(services.ResourceLimits{PidsLimit: 100})); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service with overridden limits"))
+	}
+	assertResourceLimitsBinding(t, services.ResourceLimits{PidsLimit: 100}, client.lastStartServiceArgs.ResourceLimits)
+}
+
+func TestUpdateServiceResourceLimits(t *testing.T) {
+	client := &argsRecordingTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	if _, _, err := networkCtx.AddService(service1, services.NewMockDockerContainerInitializer()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+
+	newLimits := services.ResourceLimits{MemoryLimitBytes: testMemoryLimitBytes}
+	if err := networkCtx.UpdateServiceResourceLimits(service1, newLimits); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred updating the service's resource limits"))
+	}
+	assert.Equal(t, string(service1), client.lastUpdateServiceResourceLimitsArgs.ServiceId)
+	assertResourceLimitsBinding(t, newLimits, client.lastUpdateServiceResourceLimitsArgs.ResourceLimits)
+
+	// Zero limits remove all constraints
+	if err := networkCtx.UpdateServiceResourceLimits(service1, services.ResourceLimits{}); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred removing the service's resource limits"))
+	}
+	assertResourceLimitsBinding(t, services.ResourceLimits{}, client.lastUpdateServiceResourceLimitsArgs.ResourceLimits)
+
+	// Updating a nonexistent service shouldn't reach the API container
+	client.lastUpdateServiceResourceLimitsArgs = nil
+	assert.Error(t, networkCtx.UpdateServiceResourceLimits(service2, newLimits))
+	assert.Nil(t, client.lastUpdateServiceResourceLimitsArgs)
+}
+
+func assertResourceLimitsBinding(t *testing.T, expected services.ResourceLimits, actual *bindings.ResourceLimits) {
+	if actual == nil {
+		t.Fatal(stacktrace.NewError("Expected resource limits to be sent to the API container, but they were nil"))
+	}
+	assert.Equal(t, expected.CpuMillicores, actual.CpuMillicores)
+	assert.Equal(t, expected.MemoryLimitBytes, actual.MemoryLimitBytes)
+	assert.Equal(t, expected.PidsLimit, actual.PidsLimit)
+}
@@ -0,0 +1,46 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-go/lib/core_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+)
+
+/*
+Implementation of services.ContainerAccessor that reaches a service's container via the Kurtosis API
+ */
+type serviceContainerAccessor struct {
+	client bindings.TestExecutionServiceClient
+	serviceId services.ServiceID
+}
+
+func newServiceContainerAccessor(client bindings.TestExecutionServiceClient, serviceId services.ServiceID) *serviceContainerAccessor {
+	return &serviceContainerAccessor{client: client, serviceId: serviceId}
+}
+
+func (accessor serviceContainerAccessor) ExecCommand(ctx context.Context, command []string) (int32, []byte, error) {
+	args := &bindings.ExecCommandArgs{
+		ServiceId:   string(accessor.serviceId),
+		CommandArgs: command,
+	}
+	resp, err := accessor.client.ExecCommand(ctx, args)
+	if err != nil {
+		return 0, nil, stacktrace.Propagate(err, "An error occurred executing command '%v' in service '%v'", command, accessor.serviceId)
+	}
+	return resp.ExitCode, resp.LogOutput, nil
+}
+
+func (accessor serviceContainerAccessor) GetLogs(ctx context.Context) ([]byte, error) {
+	args := &bindings.GetServiceLogsArgs{ServiceId: string(accessor.serviceId)}
+	resp, err := accessor.client.GetServiceLogs(ctx, args)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the logs of service '%v'", accessor.serviceId)
+	}
+	return resp.Logs, nil
+}
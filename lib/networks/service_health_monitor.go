@@ -0,0 +1,268 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+type ServiceHealthState string
+
+const (
+	// The service hasn't been probed yet
+	ServiceHealthStateUnknown ServiceHealthState = "UNKNOWN"
+	ServiceHealthStateAvailable ServiceHealthState = "AVAILABLE"
+	ServiceHealthStateUnavailable ServiceHealthState = "UNAVAILABLE"
+
+	// How long a single probe may take before the service is considered unavailable, so that a hung probe can't stall
+	//  the monitor
+	healthProbeTimeout = 30 * time.Second
+)
+
+/*
+Configuration for monitoring the health of services in the background while a test runs
+ */
+type HealthMonitorConfig struct {
+	// How often each service's availability will be probed
+	PollInterval time.Duration
+
+	// If true, the test will be failed as soon as a service goes from available to unavailable, unless the service
+	//  was declared as expected to go down via ServiceHealthMonitor.ExpectServiceDown
+	FailTestOnUnexpectedDown bool
+}
+
+/*
+Record of a service's health changing, as observed by the ServiceHealthMonitor
+ */
+type ServiceStateTransition struct {
+	ServiceId services.ServiceID
+	Timestamp time.Time
+	FromState ServiceHealthState
+	ToState ServiceHealthState
+
+	// If the service became unavailable, the reason its availability check failed
+	Reason string
+}
+
+/*
+Periodically probes the availability checker of every service in the network in the background, recording every
+	change in each service's health
+ */
+type ServiceHealthMonitor struct {
+	networkCtx *NetworkContext
+	pollInterval time.Duration
+
+	// Called when a service goes from available to unavailable without having been declared as expected to go down
+	onUnexpectedDown func(err error)
+
+	// Mutex protecting access to the fields below
+	mutex *sync.Mutex
+	states map[services.ServiceID]ServiceHealthState
+	expectedDownServices map[services.ServiceID]bool
+	transitions []ServiceStateTransition
+
+	// Context that in-progress probes run under, which is cancelled when the monitor is stopped so that stopping
+	//  doesn't have to wait for a slow probe
+	probesCtx context.Context
+	cancelProbes context.CancelFunc
+
+	stopChan chan struct{}
+	doneChan chan struct{}
+}
+
+func newServiceHealthMonitor(networkCtx *NetworkContext, pollInterval time.Duration, onUnexpectedDown func(err error)) *ServiceHealthMonitor {
+	probesCtx, cancelProbes := context.WithCancel(context.Background())
+	return &ServiceHealthMonitor{
+		networkCtx:           networkCtx,
+		pollInterval:         pollInterval,
+		onUnexpectedDown:     onUnexpectedDown,
+		mutex:                &sync.Mutex{},
+		states:               map[services.ServiceID]ServiceHealthState{},
+		expectedDownServices: map[services.ServiceID]bool{},
+		transitions:          []ServiceStateTransition{},
+		probesCtx:            probesCtx,
+		cancelProbes:         cancelProbes,
+		stopChan:             make(chan struct{}),
+		doneChan:             make(chan struct{}),
+	}
+}
+
+/*
+Declares that the given service is expected to go down (e.g. because the test is deliberately crashing it), so
+	that doing so won't fail the test. The declaration lasts until the service is removed via
+	NetworkContext.RemoveService, which never fails the test, or re-added.
+ */
+func (monitor *ServiceHealthMonitor) ExpectServiceDown(serviceId services.ServiceID) {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+
+	monitor.expectedDownServices[serviceId] = true
+}
+
+/*
+Resets the monitor's state for a service that was just added to the network, so that a service re-added under the ID
+	of one that was expected to go down is monitored like any other
+
+NOTE: Called with the network context's mutex held
+ */
+func (monitor *ServiceHealthMonitor) onServiceAdded(serviceId services.ServiceID) {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+
+	delete(monitor.states, serviceId)
+	delete(monitor.expectedDownServices, serviceId)
+}
+
+/*
+Drops the monitor's state for a service that was removed from the network; its recorded transitions are kept
+
+NOTE: Called with the network context's mutex held
+ */
+func (monitor *ServiceHealthMonitor) onServiceRemoved(serviceId services.ServiceID) {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+
+	delete(monitor.states, serviceId)
+	delete(monitor.expectedDownServices, serviceId)
+}
+
+/*
+Gets all the changes in service health observed so far, in the order they were observed
+ */
+func (monitor *ServiceHealthMonitor) GetStateTransitions() []ServiceStateTransition {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+
+	result := make([]ServiceStateTransition, len(monitor.transitions))
+	copy(result, monitor.transitions)
+	return result
+}
+
+/*
+Gets the most recently observed health state of the given service
+ */
+func (monitor *ServiceHealthMonitor) GetState(serviceId services.ServiceID) ServiceHealthState {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+
+	state, found := monitor.states[serviceId]
+	if !found {
+		return ServiceHealthStateUnknown
+	}
+	return state
+}
+
+func (monitor *ServiceHealthMonitor) start() {
+	go func() {
+		defer close(monitor.doneChan)
+		for {
+			monitor.probeAllServices()
+			select {
+			case <- monitor.stopChan:
+				return
+			case <- time.After(monitor.pollInterval):
+			}
+		}
+	}()
+}
+
+// Stops the monitor, cancelling any in-progress probes and blocking until they've finished
+func (monitor *ServiceHealthMonitor) stop() {
+	monitor.cancelProbes()
+	close(monitor.stopChan)
+	<- monitor.doneChan
+}
+
+func (monitor *ServiceHealthMonitor) probeAllServices() {
+	checkers := monitor.networkCtx.getAvailabilityCheckers()
+
+	// Probes can be slow (e.g. a network probe timing out), so we probe every service in parallel
+	waitGroup := sync.WaitGroup{}
+	for serviceId, checker := range checkers {
+		waitGroup.Add(1)
+		go func(serviceId services.ServiceID, checker services.AvailabilityChecker) {
+			defer waitGroup.Done()
+			probeCtx, cancelFunc := context.WithTimeout(monitor.probesCtx, healthProbeTimeout)
+			defer cancelFunc()
+			checkErr := checker.CheckAvailability(probeCtx)
+			// A probe cancelled because the monitor was stopped says nothing about the service's health
+			if monitor.probesCtx.Err() != nil {
+				return
+			}
+			unexpectedDownErr := monitor.recordProbeResult(serviceId, checker, checkErr)
+			// The callback is called without any locks held, so that it's free to use the network context
+			if unexpectedDownErr != nil && monitor.onUnexpectedDown != nil {
+				monitor.onUnexpectedDown(unexpectedDownErr)
+			}
+		}(serviceId, checker)
+	}
+	waitGroup.Wait()
+}
+
+/*
+Records the result of probing the given service, returning a non-nil error if the service unexpectedly went down
+
+NOTE: Results from a checker that's no longer in the network (because its service was removed, and maybe re-added,
+	while the probe was in progress) are discarded
+ */
+func (monitor *ServiceHealthMonitor) recordProbeResult(
+		serviceId services.ServiceID,
+		checker services.AvailabilityChecker,
+		checkErr error) error {
+	// The network context's mutex is held throughout so that the service can't be removed while we record its state
+	monitor.networkCtx.mutex.Lock()
+	defer monitor.networkCtx.mutex.Unlock()
+	if monitor.networkCtx.serviceAvailabilityCheckers[serviceId] != checker {
+		logrus.Tracef("Discarding probe result for service '%v' because it was removed while being probed", serviceId)
+		return nil
+	}
+
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+
+	newState := ServiceHealthStateAvailable
+	reason := ""
+	if checkErr != nil {
+		newState = ServiceHealthStateUnavailable
+		reason = checkErr.Error()
+	}
+
+	oldState, found := monitor.states[serviceId]
+	if !found {
+		oldState = ServiceHealthStateUnknown
+	}
+	if oldState == newState {
+		return nil
+	}
+
+	transition := ServiceStateTransition{
+		ServiceId: serviceId,
+		Timestamp: time.Now(),
+		FromState: oldState,
+		ToState:   newState,
+		Reason:    reason,
+	}
+	monitor.transitions = append(monitor.transitions, transition)
+	monitor.states[serviceId] = newState
+	logrus.Debugf("Service '%v' went from %v to %v", serviceId, oldState, newState)
+
+	isUnexpectedDown := oldState == ServiceHealthStateAvailable &&
+		newState == ServiceHealthStateUnavailable &&
+		!monitor.expectedDownServices[serviceId]
+	if !isUnexpectedDown {
+		return nil
+	}
+	return stacktrace.Propagate(
+		checkErr,
+		"Service '%v' unexpectedly went down at %v",
+		serviceId,
+		transition.Timestamp)
+}
@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testMonitorPollInterval = 10 * time.Millisecond
+
+	// How long to wait for the monitor to observe a change, and how often to look
+	testMonitorWaitTimeout = 5 * time.Second
+	testMonitorWaitTick = 5 * time.Millisecond
+
+	crashingServiceId services.ServiceID = "crashing-service"
+	expectedCrashServiceId services.ServiceID = "expected-crash-service"
+)
+
+// An availability check whose result can be changed by the test
+type toggleableCheck struct {
+	mutex *sync.Mutex
+	isAvailable bool
+}
+
+func newToggleableCheck() *toggleableCheck {
+	return &toggleableCheck{mutex: &sync.Mutex{}, isAvailable: true}
+}
+
+func (check *toggleableCheck) setAvailable(isAvailable bool) {
+	check.mutex.Lock()
+	defer check.mutex.Unlock()
+	check.isAvailable = isAvailable
+}
+
+func (check *toggleableCheck) Check(ctx context.Context, service services.Service, containerAccessor services.ContainerAccessor) error {
+	check.mutex.Lock()
+	defer check.mutex.Unlock()
+	if !check.isAvailable {
+		return stacktrace.NewError("Test service is down")
+	}
+	return nil
+}
+
+// An availability check that blocks until its context is done, like a probe of a hung service
+type blockingCheck struct {
+	// Closed when the first check starts
+	startedChan chan struct{}
+	startedOnce *sync.Once
+}
+
+func newBlockingCheck() *blockingCheck {
+	return &blockingCheck{startedChan: make(chan struct{}), startedOnce: &sync.Once{}}
+}
+
+func (check *blockingCheck) Check(ctx context.Context, service services.Service, containerAccessor services.ContainerAccessor) error {
+	check.startedOnce.Do(func() { close(check.startedChan) })
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestHealthMonitorDetectsUnexpectedDown(t *testing.T) {
+	networkCtx := NewNetworkContext(nil, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	crashingCheck := addTestMonitoredService(networkCtx, crashingServiceId)
+	expectedCrashCheck := addTestMonitoredService(networkCtx, expectedCrashServiceId)
+
+	unexpectedDownErrsMutex := &sync.Mutex{}
+	unexpectedDownErrs := []error{}
+	monitor, err := networkCtx.StartHealthMonitor(testMonitorPollInterval, func(err error) {
+		unexpectedDownErrsMutex.Lock()
+		defer unexpectedDownErrsMutex.Unlock()
+		unexpectedDownErrs = append(unexpectedDownErrs, err)
+	})
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred starting the health monitor"))
+	}
+	waitForHealthState(t, monitor, crashingServiceId, ServiceHealthStateAvailable)
+	waitForHealthState(t, monitor, expectedCrashServiceId, ServiceHealthStateAvailable)
+
+	monitor.ExpectServiceDown(expectedCrashServiceId)
+	crashingCheck.setAvailable(false)
+	expectedCrashCheck.setAvailable(false)
+	waitForHealthState(t, monitor, crashingServiceId, ServiceHealthStateUnavailable)
+	waitForHealthState(t, monitor, expectedCrashServiceId, ServiceHealthStateUnavailable)
+
+	// Stopping waits for in-progress probes, so any callbacks will have been called by the time it returns
+	networkCtx.StopHealthMonitor()
+	assert.Nil(t, networkCtx.GetHealthMonitor())
+
+	// Only the service that wasn't expected to go down should trigger the callback, and only once
+	unexpectedDownErrsMutex.Lock()
+	defer unexpectedDownErrsMutex.Unlock()
+	assert.Equal(t, 1, len(unexpectedDownErrs))
+	assert.Contains(t, unexpectedDownErrs[0].Error(), string(crashingServiceId))
+
+	// Each service goes UNKNOWN -> AVAILABLE -> UNAVAILABLE
+	crashingServiceTransitions := []ServiceStateTransition{}
+	for _, transition := range monitor.GetStateTransitions() {
+		if transition.ServiceId == crashingServiceId {
+			crashingServiceTransitions = append(crashingServiceTransitions, transition)
+		}
+	}
+	assert.Equal(t, 2, len(crashingServiceTransitions))
+	assert.Equal(t, ServiceHealthStateUnknown, crashingServiceTransitions[0].FromState)
+	assert.Equal(t, ServiceHealthStateAvailable, crashingServiceTransitions[1].FromState)
+	assert.Equal(t, ServiceHealthStateUnavailable, crashingServiceTransitions[1].ToState)
+	assert.NotEmpty(t, crashingServiceTransitions[1].Reason)
+}
+
+func TestHealthMonitorForgetsRemovedServices(t *testing.T) {
+	networkCtx := NewNetworkContext(&argsRecordingTestClient{}, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	unexpectedDownErrsMutex := &sync.Mutex{}
+	unexpectedDownErrs := []error{}
+	monitor, err := networkCtx.StartHealthMonitor(testMonitorPollInterval, func(err error) {
+		unexpectedDownErrsMutex.Lock()
+		defer unexpectedDownErrsMutex.Unlock()
+		unexpectedDownErrs = append(unexpectedDownErrs, err)
+	})
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred starting the health monitor"))
+	}
+	defer networkCtx.StopHealthMonitor()
+
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.AvailabilityCheck = newToggleableCheck()
+	if _, _, err := networkCtx.AddService(service1, initializer); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+	waitForHealthState(t, monitor, service1, ServiceHealthStateAvailable)
+
+	monitor.ExpectServiceDown(service1)
+	if err := networkCtx.RemoveService(service1, 0); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred removing the service"))
+	}
+	assert.Equal(t, ServiceHealthStateUnknown, monitor.GetState(service1))
+	monitor.mutex.Lock()
+	assert.Empty(t, monitor.expectedDownServices)
+	monitor.mutex.Unlock()
+
+	// A service re-added under the same ID shouldn't inherit the old service's expectation of going down
+	reAddedCheck := newToggleableCheck()
+	initializer.AvailabilityCheck = reAddedCheck
+	if _, _, err := networkCtx.AddService(service1, initializer); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred re-adding the service"))
+	}
+	waitForHealthState(t, monitor, service1, ServiceHealthStateAvailable)
+	reAddedCheck.setAvailable(false)
+	assert.Eventually(
+		t,
+		func() bool {
+			unexpectedDownErrsMutex.Lock()
+			defer unexpectedDownErrsMutex.Unlock()
+			return len(unexpectedDownErrs) == 1
+		},
+		testMonitorWaitTimeout,
+		testMonitorWaitTick,
+		"Expected the re-added service going down to be reported as unexpected")
+}
+
+func TestStopHealthMonitorCancelsInProgressProbes(t *testing.T) {
+	networkCtx := NewNetworkContext(nil, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	check := newBlockingCheck()
+	service := services.NewMockService(service1, "1.2.3.4", 1)
+	networkCtx.services[service1] = service
+	networkCtx.serviceAvailabilityCheckers[service1] = services.NewAvailabilityCheckerWithCheck(service1, service, check, nil)
+
+	unexpectedDownErrsMutex := &sync.Mutex{}
+	unexpectedDownErrs := []error{}
+	monitor, err := networkCtx.StartHealthMonitor(testMonitorPollInterval, func(err error) {
+		unexpectedDownErrsMutex.Lock()
+		defer unexpectedDownErrsMutex.Unlock()
+		unexpectedDownErrs = append(unexpectedDownErrs, err)
+	})
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred starting the health monitor"))
+	}
+	select {
+	case <-check.startedChan:
+	case <-time.After(testMonitorWaitTimeout):
+		t.Fatal("The health monitor never probed the service")
+	}
+
+	stoppedChan := make(chan struct{})
+	go func() {
+		networkCtx.StopHealthMonitor()
+		close(stoppedChan)
+	}()
+	select {
+	case <-stoppedChan:
+	case <-time.After(testMonitorWaitTimeout):
+		t.Fatal("Stopping the health monitor didn't cancel the in-progress probe")
+	}
+
+	// The cancelled probe shouldn't have been recorded as the service going down
+	assert.Equal(t, ServiceHealthStateUnknown, monitor.GetState(service1))
+	assert.Empty(t, monitor.GetStateTransitions())
+	unexpectedDownErrsMutex.Lock()
+	defer unexpectedDownErrsMutex.Unlock()
+	assert.Empty(t, unexpectedDownErrs)
+}
+
+func waitForHealthState(t *testing.T, monitor *ServiceHealthMonitor, serviceId services.ServiceID, expectedState ServiceHealthState) {
+	assert.Eventually(
+		t,
+		func() bool { return monitor.GetState(serviceId) == expectedState },
+		testMonitorWaitTimeout,
+		testMonitorWaitTick,
+		"Service '%v' never reached health state %v",
+		serviceId,
+		expectedState)
+}
+
+func addTestMonitoredService(networkCtx *NetworkContext, serviceId services.ServiceID) *toggleableCheck {
+	check := newToggleableCheck()
+	service := services.NewMockService(serviceId, "1.2.3.4", 1)
+	networkCtx.services[serviceId] = service
+	networkCtx.serviceAvailabilityCheckers[serviceId] = services.NewAvailabilityCheckerWithCheck(serviceId, service, check, nil)
+	return check
+}
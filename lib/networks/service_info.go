@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"encoding/json"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"time"
+)
+
+/*
+Describes a service in the network, as returned by NetworkContext.GetServiceInfo
+ */
+type ServiceInfo struct {
+	ServiceID services.ServiceID `json:"serviceId"`
+
+	IPAddress string `json:"ipAddress"`
+
+	DockerImage string `json:"dockerImage"`
+
+	// Nil if the container was started with the command specified by its image
+	StartCommand []string `json:"startCommand"`
+
+	UsedPorts []services.Port `json:"usedPorts"`
+
+	// The partition the service is currently in, which is updated when the network is repartitioned
+	PartitionID PartitionID `json:"partitionId"`
+
+	// Mapping of filesArtifactId -> the mountpoint of the artifact's contents in the service's container
+	FilesArtifactMountpoints map[services.FilesArtifactID]string `json:"filesArtifactMountpoints"`
+
+	// Mapping of sharedVolumeId -> the mountpoint of the shared volume in the service's container
+	SharedVolumeMountpoints map[services.SharedVolumeID]string `json:"sharedVolumeMountpoints"`
+
+	Labels map[string]string `json:"labels"`
+
+	StartTime time.Time `json:"startTime"`
+}
+
+/*
+A snapshot of everything in the network, as returned by NetworkContext.GetNetworkState
+ */
+type NetworkState struct {
+	SnapshotTime time.Time `json:"snapshotTime"`
+
+	Services map[services.ServiceID]*ServiceInfo `json:"services"`
+
+	// Mapping of partitionId -> IDs of the services in the partition, sorted
+	Partitions map[PartitionID][]services.ServiceID `json:"partitions"`
+}
+
+/*
+Gets a snapshot of every service in the network, grouped by partition
+ */
+func (networkCtx *NetworkContext) GetNetworkState() *NetworkState {
+	serviceInfos := networkCtx.GetAllServiceInfo()
+
+	partitionServiceIdSets := map[PartitionID]map[services.ServiceID]bool{}
+	for serviceId, serviceInfo := range serviceInfos {
+		serviceIdSet, found := partitionServiceIdSets[serviceInfo.PartitionID]
+		if !found {
+			serviceIdSet = map[services.ServiceID]bool{}
+			partitionServiceIdSets[serviceInfo.PartitionID] = serviceIdSet
+		}
+		serviceIdSet[serviceId] = true
+	}
+	partitions := map[PartitionID][]services.ServiceID{}
+	for partitionId, serviceIdSet := range partitionServiceIdSets {
+		partitions[partitionId] = getSortedServiceIds(serviceIdSet)
+	}
+
+	return &NetworkState{
+		SnapshotTime: time.Now(),
+		Services:     serviceInfos,
+		Partitions:   partitions,
+	}
+}
+
+/*
+Serializes a snapshot of the network's state to indented JSON, for debugging & reporting
+ */
+func (networkCtx *NetworkContext) DumpNetworkStateJson() ([]byte, error) {
+	stateBytes, err := json.MarshalIndent(networkCtx.GetNetworkState(), "", "  ")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred serializing the network state")
+	}
+	return stateBytes, nil
+}
+
+// Deep-copies the service info, so that callers can't modify the NetworkContext's state
+func (info ServiceInfo) clone() *ServiceInfo {
+	var startCommand []string
+	if info.StartCommand != nil {
+		startCommand = append([]string{}, info.StartCommand...)
+	}
+	usedPorts := append([]services.Port{}, info.UsedPorts...)
+	filesArtifactMountpoints := map[services.FilesArtifactID]string{}
+	for filesArtifactId, mountpoint := range info.FilesArtifactMountpoints {
+		filesArtifactMountpoints[filesArtifactId] = mountpoint
+	}
+	sharedVolumeMountpoints := map[services.SharedVolumeID]string{}
+	for volumeId, mountpoint := range info.SharedVolumeMountpoints {
+		sharedVolumeMountpoints[volumeId] = mountpoint
+	}
+	labels := map[string]string{}
+	for key, value := range info.Labels {
+		labels[key] = value
+	}
+	return &ServiceInfo{
+		ServiceID:                info.ServiceID,
+		IPAddress:                info.IPAddress,
+		DockerImage:              info.DockerImage,
+		StartCommand:             startCommand,
+		UsedPorts:                usedPorts,
+		PartitionID:              info.PartitionID,
+		FilesArtifactMountpoints: filesArtifactMountpoints,
+		SharedVolumeMountpoints:  sharedVolumeMountpoints,
+		Labels:                   labels,
+		StartTime:                info.StartTime,
+	}
+}
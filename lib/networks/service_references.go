@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"regexp"
+	"strings"
+)
+
+const (
+	serviceReferenceFieldSeparator = ":"
+
+	serviceReferenceIpAttribute = "ip"
+	serviceReferencePortAttribute = "port"
+
+	// Prefix of an escaped reference, which is output literally with one fewer '$' rather than being resolved
+	escapedServiceReferencePrefix = "$$"
+)
+
+/*
+Matches references to other services in start commands, env var values, and templated files, which are resolved by
+	the NetworkContext when a service is started:
+
+	${service:<service ID>:ip}					The IP address of the service
+	${service:<service ID>:port:<port name>}	The number of the service's port with the given name
+
+A reference can be escaped by doubling its '$', e.g. "$${service:datastore:ip}" is output as "${service:datastore:ip}"
+ */
+var serviceReferenceRegex = regexp.MustCompile(`\$?\$\{service:([^}]*)\}`)
+
+/*
+Replaces every reference to another service in the given string with its value
+
+Args:
+	str: The string containing the references
+	serviceData: The services that can be referenced, keyed by ID
+
+Returns:
+	The string with the references resolved, or an error if a reference is malformed or refers to a service or port
+		that doesn't exist
+ */
+func resolveServiceReferences(str string, serviceData map[services.ServiceID]services.FileTemplateServiceData) (string, error) {
+	problems := []string{}
+	result := serviceReferenceRegex.ReplaceAllStringFunc(str, func(reference string) string {
+		if strings.HasPrefix(reference, escapedServiceReferencePrefix) {
+			return strings.TrimPrefix(reference, "$")
+		}
+		referenceBody := serviceReferenceRegex.FindStringSubmatch(reference)[1]
+		value, err := resolveServiceReference(referenceBody, serviceData)
+		if err != nil {
+			problems = append(problems, stacktrace.Propagate(err, "Service reference '%v' couldn't be resolved", reference).Error())
+			return reference
+		}
+		return value
+	})
+	if len(problems) > 0 {
+		return "", stacktrace.NewError("Errors occurred resolving service references:\n%v", strings.Join(problems, "\n"))
+	}
+	return result, nil
+}
+
+// Resolves the body of a reference, e.g. "datastore:port:http"
+func resolveServiceReference(referenceBody string, serviceData map[services.ServiceID]services.FileTemplateServiceData) (string, error) {
+	fields := strings.Split(referenceBody, serviceReferenceFieldSeparator)
+	serviceId := services.ServiceID(fields[0])
+	if serviceId == "" {
+		return "", stacktrace.NewError("The reference has no service ID")
+	}
+	data, found := serviceData[serviceId]
+	if !found {
+		return "", stacktrace.NewError("No service with ID '%v' is in the network", serviceId)
+	}
+
+	attributeFields := fields[1:]
+	if len(attributeFields) == 1 && attributeFields[0] == serviceReferenceIpAttribute {
+		return data.IPAddress, nil
+	}
+	if len(attributeFields) == 2 && attributeFields[0] == serviceReferencePortAttribute {
+		port, err := services.GetPortByName(data.UsedPorts, attributeFields[1])
+		if err != nil {
+			return "", stacktrace.Propagate(err, "An error occurred getting port '%v' of service '%v'", attributeFields[1], serviceId)
+		}
+		return fmt.Sprintf("%v", port.Number), nil
+	}
+	return "", stacktrace.NewError(
+		"Unrecognized reference attribute '%v'; expected '%v' or '%v%v<port name>'",
+		strings.Join(attributeFields, serviceReferenceFieldSeparator),
+		serviceReferenceIpAttribute,
+		serviceReferencePortAttribute,
+		serviceReferenceFieldSeparator)
+}
@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+var testReferenceableServiceData = map[services.ServiceID]services.FileTemplateServiceData{
+	"datastore": {
+		IPAddress: "5.6.7.8",
+		UsedPorts: []services.Port{{Number: 1323, Protocol: services.TCP, Name: "http"}},
+	},
+}
+
+func TestResolveServiceReferences(t *testing.T) {
+	resolved, err := resolveServiceReferences(
+		"http://${service:datastore:ip}:${service:datastore:port:http}/health",
+		testReferenceableServiceData)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred resolving the service references"))
+	}
+	assert.Equal(t, "http://5.6.7.8:1323/health", resolved)
+}
+
+func TestResolveServiceReferencesWithoutReferences(t *testing.T) {
+	str := "--ip=SERVICEIP --config={{.IPAddress}} ${HOME}"
+	resolved, err := resolveServiceReferences(str, testReferenceableServiceData)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred resolving the service references"))
+	}
+	assert.Equal(t, str, resolved)
+}
+
+func TestResolveServiceReferencesWithEscapedReferences(t *testing.T) {
+	resolved, err := resolveServiceReferences(
+		"echo '$${service:datastore:ip}' $${service:nonexistent:ip} ${service:datastore:ip} $$$${service:datastore:ip}",
+		testReferenceableServiceData)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred resolving the service references"))
+	}
+	assert.Equal(t, "echo '${service:datastore:ip}' ${service:nonexistent:ip} 5.6.7.8 $$${service:datastore:ip}", resolved)
+}
+
+func TestResolveServiceReferencesReportsEveryProblem(t *testing.T) {
+	_, err := resolveServiceReferences(
+		"${service:nonexistent:ip} ${service:datastore:port:grpc} ${service:datastore:hostname} ${service:}",
+		testReferenceableServiceData)
+	assert.Error(t, err)
+	errStr := err.Error()
+	assert.Contains(t, errStr, "No service with ID 'nonexistent' is in the network")
+	assert.Contains(t, errStr, "An error occurred getting port 'grpc' of service 'datastore'")
+	assert.Contains(t, errStr, "Unrecognized reference attribute 'hostname'")
+	assert.Contains(t, errStr, "The reference has no service ID")
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-go/lib/core_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/kurtosis-tech/kurtosis-go/lib/test_suite_docker_consts/test_suite_container_mountpoints"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"path"
+	"strings"
+)
+
+/*
+Gets the directory backing the given shared volume, so that the testsuite can read the files that services write to it
+	or populate it before the services that mount it are started. The volume is created if no service has mounted it yet.
+
+Args:
+	volumeId: The ID of the shared volume, as declared in services.SharedVolumesInitializer.GetSharedVolumeMountpoints
+
+Return:
+	The absolute path *on the testsuite container* (underneath the suite execution volume mountpoint) of the directory
+		containing the shared volume's contents
+ */
+func (networkCtx *NetworkContext) GetSharedVolumeDirpath(volumeId services.SharedVolumeID) (string, error) {
+	relativeDirpath, err := networkCtx.getOrCreateSharedVolume(volumeId)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred getting shared volume '%v'", volumeId)
+	}
+	return path.Join(test_suite_container_mountpoints.SuiteExVolMountpoint, relativeDirpath), nil
+}
+
+// ====================================================================================================
+//                                       Private helper methods
+// ====================================================================================================
+/*
+Creates the shared volumes that the given mountpoints refer to (if they don't exist yet), and converts the mountpoints
+	into the form the Kurtosis API expects
+ */
+func (networkCtx *NetworkContext) getSharedVolumeMountDirpaths(sharedVolumeMountpoints map[services.SharedVolumeID]string) (map[string]string, error) {
+	result := map[string]string{}
+	for volumeId, mountDirpath := range sharedVolumeMountpoints {
+		if _, err := networkCtx.getOrCreateSharedVolume(volumeId); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred getting shared volume '%v'", volumeId)
+		}
+		result[string(volumeId)] = mountDirpath
+	}
+	return result, nil
+}
+
+/*
+Gets the dirpath (relative to the suite execution volume) of the given shared volume, creating it with the Kurtosis API
+	if this is the first time it's been requested in the test
+
+NOTE: The mutex is held while the volume is created, so that concurrently-started services can't create it twice
+ */
+func (networkCtx *NetworkContext) getOrCreateSharedVolume(volumeId services.SharedVolumeID) (string, error) {
+	if strings.TrimSpace(string(volumeId)) == "" {
+		return "", stacktrace.NewError("Shared volume IDs can't be empty")
+	}
+
+	networkCtx.mutex.Lock()
+	defer networkCtx.mutex.Unlock()
+
+	if relativeDirpath, found := networkCtx.sharedVolumeRelativeDirpaths[volumeId]; found {
+		return relativeDirpath, nil
+	}
+
+	logrus.Debugf("Creating shared volume '%v'...", volumeId)
+	args := &bindings.CreateSharedVolumeArgs{
+		VolumeId: string(volumeId),
+	}
+	resp, err := networkCtx.client.CreateSharedVolume(context.Background(), args)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred creating shared volume '%v' with the Kurtosis API", volumeId)
+	}
+	networkCtx.sharedVolumeRelativeDirpaths[volumeId] = resp.RelativeDirpath
+	logrus.Debugf("Successfully created shared volume '%v'", volumeId)
+	return resp.RelativeDirpath, nil
+}
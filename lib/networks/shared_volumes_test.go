@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-go/lib/core_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/kurtosis-tech/kurtosis-go/lib/test_suite_docker_consts/test_suite_container_mountpoints"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"path"
+	"sync"
+	"testing"
+)
+
+const (
+	testSharedVolumeId services.SharedVolumeID = "keystore"
+)
+
+// Records the shared volumes that were created, placing each one in a directory named after its ID
+type sharedVolumesTestClient struct {
+	argsRecordingTestClient
+
+	mutex sync.Mutex
+	createdVolumeIds []services.SharedVolumeID
+}
+
+func (client *sharedVolumesTestClient) CreateSharedVolume(ctx context.Context, in *bindings.CreateSharedVolumeArgs, opts ...grpc.CallOption) (*bindings.CreateSharedVolumeResponse, error) {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.createdVolumeIds = append(client.createdVolumeIds, services.SharedVolumeID(in.VolumeId))
+	return &bindings.CreateSharedVolumeResponse{
+		RelativeDirpath: path.Join("shared-volumes", in.VolumeId),
+	}, nil
+}
+
+func newSharedVolumeTestInitializer(mountDirpath string) *services.MockDockerContainerInitializer {
+	initializer := services.NewMockDockerContainerInitializer()
+	initializer.SharedVolumeMountpoints = map[services.SharedVolumeID]string{testSharedVolumeId: mountDirpath}
+	return initializer
+}
+
+func TestAddServicesMountSharedVolume(t *testing.T) {
+	client := &sharedVolumesTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+	initializers := map[services.ServiceID]services.DockerContainerInitializer{
+		service1: newSharedVolumeTestInitializer("/keystore"),
+		service2: newSharedVolumeTestInitializer("/data/keystore"),
+	}
+	if _, _, err := networkCtx.AddServices(initializers); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the services"))
+	}
+
+	// Both services mount the same volume, so it should only have been created once
+	assert.Equal(t, []services.SharedVolumeID{testSharedVolumeId}, client.createdVolumeIds)
+
+	serviceInfo, err := networkCtx.GetServiceInfo(service2)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the service info"))
+	}
+	assert.Equal(t, map[services.SharedVolumeID]string{testSharedVolumeId: "/data/keystore"}, serviceInfo.SharedVolumeMountpoints)
+
+	dirpath, err := networkCtx.GetSharedVolumeDirpath(testSharedVolumeId)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the shared volume's dirpath"))
+	}
+	assert.Equal(t, path.Join(test_suite_container_mountpoints.SuiteExVolMountpoint, "shared-volumes", "keystore"), dirpath)
+	assert.Equal(t, 1, len(client.createdVolumeIds))
+}
+
+func TestSharedVolumeCreatedByTestsuiteIsMounted(t *testing.T) {
+	client := &sharedVolumesTestClient{}
+	networkCtx := NewNetworkContext(client, map[services.FilesArtifactID]string{}, map[services.FilesArtifactID]string{})
+
+	// The testsuite can populate the volume before any service mounts it
+	if _, err := networkCtx.GetSharedVolumeDirpath(testSharedVolumeId); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the shared volume's dirpath"))
+	}
+	if _, _, err := networkCtx.AddService(service1, newSharedVolumeTestInitializer("/keystore")); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred adding the service"))
+	}
+	assert.Equal(t, []services.SharedVolumeID{testSharedVolumeId}, client.createdVolumeIds)
+	assert.Equal(t, map[string]string{string(testSharedVolumeId): "/keystore"}, client.lastStartServiceArgs.SharedVolumeMountDirpaths)
+
+	_, err := networkCtx.GetSharedVolumeDirpath("")
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+)
+
+/*
+Adds a service defined by the spec with the given name (see services.LoadServiceSpecs), e.g.:
+
+	specs, err := services.LoadServiceSpecs("/static/services.yaml")
+	...
+	datastore, checker, err := networkCtx.AddServiceFromSpec("datastore-1", specs, "datastore", WithStartupWait(timeout, backoff))
+
+The spec's environment variables are set in the container, but any passed via the WithEnvVars option take precedence.
+
+Args:
+	serviceId: The service ID that will be used to identify this node in the network
+	specs: The specs to look up the spec in
+	specName: The name of the spec in the specs
+	options: Options customizing how the service is added (see add_service_options.go)
+ */
+func (networkCtx *NetworkContext) AddServiceFromSpec(
+		serviceId services.ServiceID,
+		specs services.ServiceSpecs,
+		specName string,
+		options ...AddServiceOption) (*services.GenericService, services.AvailabilityChecker, error) {
+	spec, err := specs.Get(specName)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred getting the spec for service '%v'", serviceId)
+	}
+	initializer, err := spec.NewInitializer()
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred creating the initializer from spec '%v'", specName)
+	}
+
+	// The spec's env vars go first so that the caller's options override them
+	allOptions := append([]AddServiceOption{WithEnvVars(spec.Env)}, options...)
+	service, availabilityChecker, err := AddTypedService[*services.GenericService](networkCtx, serviceId, initializer, allOptions...)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred adding service '%v' from spec '%v'", serviceId, specName)
+	}
+	return service, availabilityChecker, nil
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"archive/tar"
+	"github.com/palantir/stacktrace"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+/*
+Extracts the TAR archive at the given filepath into the given directory, creating the directory if it doesn't exist
+ */
+func extractTarArchive(archiveFilepath string, destDirpath string) error {
+	archiveFp, err := os.Open(archiveFilepath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred opening TAR archive '%v'", archiveFilepath)
+	}
+	defer archiveFp.Close()
+
+	if err := os.MkdirAll(destDirpath, os.ModePerm); err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating extraction directory '%v'", destDirpath)
+	}
+
+	cleanedDestDirpath := filepath.Clean(destDirpath)
+	tarReader := tar.NewReader(archiveFp)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred reading the next header of TAR archive '%v'", archiveFilepath)
+		}
+
+		entryFilepath := filepath.Join(cleanedDestDirpath, header.Name)
+		// Guard against archive entries like "../../etc/passwd" that would escape the destination directory
+		if !isWithinDirpath(entryFilepath, cleanedDestDirpath) {
+			return stacktrace.NewError(
+				"TAR archive entry '%v' would be extracted outside of destination directory '%v'",
+				header.Name,
+				destDirpath)
+		}
+		// The check above is purely lexical, so an earlier symlink entry (e.g. "a -> /etc") could otherwise be used to
+		//  write through to anywhere (e.g. with a later "a/passwd" entry)
+		if err := checkNoSymlinksInPath(entryFilepath, cleanedDestDirpath); err != nil {
+			return stacktrace.Propagate(err, "TAR archive entry '%v' would be extracted through a symlink", header.Name)
+		}
+
+		entryMode := os.FileMode(header.Mode).Perm()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(entryFilepath, entryMode | 0700); err != nil {
+				return stacktrace.Propagate(err, "An error occurred creating directory '%v'", entryFilepath)
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(entryFilepath), os.ModePerm); err != nil {
+				return stacktrace.Propagate(err, "An error occurred creating the parent directory of '%v'", entryFilepath)
+			}
+			if err := extractTarFile(tarReader, entryFilepath, entryMode); err != nil {
+				return stacktrace.Propagate(err, "An error occurred extracting file '%v'", entryFilepath)
+			}
+		case tar.TypeSymlink:
+			if filepath.IsAbs(header.Linkname) {
+				return stacktrace.NewError(
+					"TAR archive symlink '%v' has absolute target '%v', which isn't allowed",
+					header.Name,
+					header.Linkname)
+			}
+			if !isWithinDirpath(filepath.Join(filepath.Dir(entryFilepath), header.Linkname), cleanedDestDirpath) {
+				return stacktrace.NewError(
+					"TAR archive symlink '%v' has target '%v', which is outside of destination directory '%v'",
+					header.Name,
+					header.Linkname,
+					destDirpath)
+			}
+			if err := os.MkdirAll(filepath.Dir(entryFilepath), os.ModePerm); err != nil {
+				return stacktrace.Propagate(err, "An error occurred creating the parent directory of '%v'", entryFilepath)
+			}
+			if err := os.Symlink(header.Linkname, entryFilepath); err != nil {
+				return stacktrace.Propagate(err, "An error occurred creating symlink '%v' -> '%v'", entryFilepath, header.Linkname)
+			}
+		default:
+			// Other entry types (devices, FIFOs, etc.) don't make sense outside the container, so we skip them
+			continue
+		}
+	}
+	return nil
+}
+
+func extractTarFile(tarReader *tar.Reader, destFilepath string, mode os.FileMode) error {
+	destFp, err := os.OpenFile(destFilepath, os.O_CREATE | os.O_TRUNC | os.O_WRONLY, mode)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred opening file '%v' for writing", destFilepath)
+	}
+	defer destFp.Close()
+
+	if _, err := io.Copy(destFp, tarReader); err != nil {
+		return stacktrace.Propagate(err, "An error occurred writing the contents of file '%v'", destFilepath)
+	}
+	return nil
+}
+
+// Returns true if the given (cleaned) filepath is the given (cleaned) directory or somewhere underneath it
+func isWithinDirpath(filepathToCheck string, dirpath string) bool {
+	return filepathToCheck == dirpath || strings.HasPrefix(filepathToCheck, dirpath + string(os.PathSeparator))
+}
+
+/*
+Checks that none of the already-existing components of the given filepath underneath the given directory (including
+	the filepath itself) are symlinks, so that nothing gets written through a symlink
+ */
+func checkNoSymlinksInPath(filepathToCheck string, dirpath string) error {
+	relativeFilepath, err := filepath.Rel(dirpath, filepathToCheck)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the path of '%v' relative to '%v'", filepathToCheck, dirpath)
+	}
+	if relativeFilepath == "." {
+		return nil
+	}
+
+	currentFilepath := dirpath
+	for _, component := range strings.Split(relativeFilepath, string(os.PathSeparator)) {
+		currentFilepath = filepath.Join(currentFilepath, component)
+		info, err := os.Lstat(currentFilepath)
+		if os.IsNotExist(err) {
+			// Nothing below a nonexistent path can exist either
+			return nil
+		}
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred getting info about '%v'", currentFilepath)
+		}
+		if info.Mode() & os.ModeSymlink != 0 {
+			return stacktrace.NewError("'%v' is a symlink", currentFilepath)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"archive/tar"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	testArchiveFilename = "archive.tar"
+	testFileContents = "some-contents"
+)
+
+func TestExtractTarArchive(t *testing.T) {
+	tempDirpath, err := ioutil.TempDir("", "tar-extraction-test")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the temporary directory"))
+	}
+	defer os.RemoveAll(tempDirpath)
+
+	archiveFilepath := filepath.Join(tempDirpath, testArchiveFilename)
+	writeTestTarArchive(t, archiveFilepath, []*tar.Header{
+		{Name: "output/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "output/nested/", Typeflag: tar.TypeDir, Mode: 0755},
+		{Name: "output/nested/script.sh", Typeflag: tar.TypeReg, Mode: 0744, Size: int64(len(testFileContents))},
+	})
+
+	destDirpath := filepath.Join(tempDirpath, "extracted")
+	if err := extractTarArchive(archiveFilepath, destDirpath); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred extracting the archive"))
+	}
+
+	extractedFilepath := filepath.Join(destDirpath, "output", "nested", "script.sh")
+	contents, err := ioutil.ReadFile(extractedFilepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading the extracted file"))
+	}
+	assert.Equal(t, testFileContents, string(contents))
+
+	fileInfo, err := os.Stat(extractedFilepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting info about the extracted file"))
+	}
+	assert.Equal(t, os.FileMode(0744), fileInfo.Mode().Perm())
+}
+
+func TestExtractTarArchiveRejectsPathTraversal(t *testing.T) {
+	tempDirpath, err := ioutil.TempDir("", "tar-extraction-test")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the temporary directory"))
+	}
+	defer os.RemoveAll(tempDirpath)
+
+	archiveFilepath := filepath.Join(tempDirpath, testArchiveFilename)
+	writeTestTarArchive(t, archiveFilepath, []*tar.Header{
+		{Name: "../escaped.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(testFileContents))},
+	})
+
+	destDirpath := filepath.Join(tempDirpath, "extracted")
+	assert.Error(t, extractTarArchive(archiveFilepath, destDirpath))
+
+	_, err = os.Stat(filepath.Join(tempDirpath, "escaped.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestExtractTarArchiveRejectsSymlinkEscapes(t *testing.T) {
+	tempDirpath, err := ioutil.TempDir("", "tar-extraction-test")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the temporary directory"))
+	}
+	defer os.RemoveAll(tempDirpath)
+
+	outsideDirpath := filepath.Join(tempDirpath, "outside")
+	if err := os.Mkdir(outsideDirpath, 0755); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the outside directory"))
+	}
+
+	maliciousArchives := map[string][]*tar.Header{
+		"absolute-symlink-target": {
+			{Name: "a", Typeflag: tar.TypeSymlink, Linkname: outsideDirpath},
+			{Name: "a/escaped.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(testFileContents))},
+		},
+		"relative-symlink-target": {
+			{Name: "nested/a", Typeflag: tar.TypeSymlink, Linkname: "../../outside"},
+			{Name: "nested/a/escaped.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(testFileContents))},
+		},
+		// The symlink's target is inside the destination directory, but writing through it still isn't allowed
+		"write-through-symlinked-parent": {
+			{Name: "real/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "link", Typeflag: tar.TypeSymlink, Linkname: "real"},
+			{Name: "link/escaped.txt", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(testFileContents))},
+		},
+	}
+	for name, headers := range maliciousArchives {
+		archiveFilepath := filepath.Join(tempDirpath, name + ".tar")
+		writeTestTarArchive(t, archiveFilepath, headers)
+
+		destDirpath := filepath.Join(tempDirpath, "extracted-" + name)
+		assert.Error(t, extractTarArchive(archiveFilepath, destDirpath), "Expected archive '%v' to be rejected", name)
+	}
+
+	_, err = os.Stat(filepath.Join(outsideDirpath, "escaped.txt"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(tempDirpath, "extracted-write-through-symlinked-parent", "real", "escaped.txt"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestExtractTarArchiveAllowsInternalSymlinks(t *testing.T) {
+	tempDirpath, err := ioutil.TempDir("", "tar-extraction-test")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the temporary directory"))
+	}
+	defer os.RemoveAll(tempDirpath)
+
+	archiveFilepath := filepath.Join(tempDirpath, testArchiveFilename)
+	writeTestTarArchive(t, archiveFilepath, []*tar.Header{
+		{Name: "config/app.yml", Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(testFileContents))},
+		{Name: "config/current.yml", Typeflag: tar.TypeSymlink, Linkname: "app.yml"},
+	})
+
+	destDirpath := filepath.Join(tempDirpath, "extracted")
+	if err := extractTarArchive(archiveFilepath, destDirpath); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred extracting the archive"))
+	}
+	contents, err := ioutil.ReadFile(filepath.Join(destDirpath, "config", "current.yml"))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading the extracted file through the symlink"))
+	}
+	assert.Equal(t, testFileContents, string(contents))
+}
+
+// Writes a TAR archive with the given headers, where each regular file gets the test file contents
+func writeTestTarArchive(t *testing.T, archiveFilepath string, headers []*tar.Header) {
+	archiveFp, err := os.Create(archiveFilepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the test archive"))
+	}
+	defer archiveFp.Close()
+
+	tarWriter := tar.NewWriter(archiveFp)
+	for _, header := range headers {
+		if err := tarWriter.WriteHeader(header); err != nil {
+			t.Fatal(stacktrace.Propagate(err, "An error occurred writing header '%v'", header.Name))
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := tarWriter.Write([]byte(testFileContents)); err != nil {
+				t.Fatal(stacktrace.Propagate(err, "An error occurred writing the contents of '%v'", header.Name))
+			}
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred closing the TAR writer"))
+	}
+}
@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks
+
+import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"github.com/palantir/stacktrace"
+)
+
+// NOTE: These are functions rather than NetworkContext methods because Go methods can't have type parameters
+
+/*
+Same as NetworkContext.AddService, but returns the service as the type created by the initializer, e.g.:
+
+	datastoreService, checker, err := networks.AddTypedService(networkCtx, serviceId, datastoreInitializer)
+ */
+func AddTypedService[S services.Service](
+		networkCtx *NetworkContext,
+		serviceId services.ServiceID,
+		initializer services.TypedDockerContainerInitializer[S],
+		options ...AddServiceOption) (S, services.AvailabilityChecker, error) {
+	var emptyService S
+	service, availabilityChecker, err := networkCtx.AddService(
+		serviceId,
+		services.NewUntypedDockerContainerInitializer(initializer),
+		options...)
+	if err != nil {
+		return emptyService, nil, stacktrace.Propagate(err, "An error occurred adding service '%v'", serviceId)
+	}
+	castedService, ok := service.(S)
+	if !ok {
+		return emptyService, nil, stacktrace.NewError(
+			"Service '%v' was of type %T rather than the type created by its initializer; this is a bug in Kurtosis",
+			serviceId,
+			service)
+	}
+	return castedService, availabilityChecker, nil
+}
+
+/*
+Same as NetworkContext.GetService, but returns an error rather than panicking if the service isn't of the requested type
+ */
+func GetTypedService[S services.Service](networkCtx *NetworkContext, serviceId services.ServiceID) (S, error) {
+	var emptyService S
+	service, err := networkCtx.GetService(serviceId)
+	if err != nil {
+		return emptyService, stacktrace.Propagate(err, "An error occurred getting service '%v'", serviceId)
+	}
+	castedService, ok := service.(S)
+	if !ok {
+		return emptyService, stacktrace.NewError("Service '%v' is of type %T, not %T", serviceId, service, emptyService)
+	}
+	return castedService, nil
+}
@@ -0,0 +1,265 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// How long a single network probe (HTTP request, TCP dial, gRPC health call) may take before it's considered failed
+	probeTimeout = 5 * time.Second
+
+	// Command exit code indicating success
+	successExitCode = 0
+)
+
+/*
+Gives availability checks access to the container of the service being checked, for checks that need more than
+	network access to the service. NetworkContext provides the implementation of this interface.
+ */
+type ContainerAccessor interface {
+	// Executes the given command inside the service's running container, returning its exit code & output; the
+	//  command is abandoned when the context is done
+	ExecCommand(ctx context.Context, command []string) (int32, []byte, error)
+
+	// Gets the logs that the service's container has output so far, giving up when the context is done
+	GetLogs(ctx context.Context) ([]byte, error)
+}
+
+/*
+A strategy for determining whether a service is available, selected per service by
+	AvailabilityCheckInitializer.GetAvailabilityCheck
+ */
+type AvailabilityCheck interface {
+	/*
+	Checks whether the given service is available
+
+	Args:
+		ctx: Context for the check; network probes are cancelled when it's done
+		service: The service being checked
+		containerAccessor: Access to the service's container, which is nil if the checker wasn't given one (e.g. one
+			created with NewDefaultAvailabilityChecker)
+
+	Returns:
+		Nil if the service is available, or an error describing why it isn't
+	 */
+	Check(ctx context.Context, service Service, containerAccessor ContainerAccessor) error
+}
+
+// ====================================================================================================
+//                                        IsAvailable check
+// ====================================================================================================
+/*
+The default check, which delegates to the service's own IsAvailable method
+ */
+type IsAvailableCheck struct{}
+
+func NewIsAvailableCheck() *IsAvailableCheck {
+	return &IsAvailableCheck{}
+}
+
+func (check IsAvailableCheck) Check(ctx context.Context, service Service, containerAccessor ContainerAccessor) error {
+	if !service.IsAvailable() {
+		return stacktrace.NewError("The service's IsAvailable method returned false")
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                            HTTP check
+// ====================================================================================================
+/*
+Checks that an HTTP GET against the service returns the expected status code and, optionally, a body matching
+	a regex
+ */
+type HttpCheck struct {
+	port int
+	urlPath string
+	expectedStatusCode int
+	// If nil, the body isn't checked
+	bodyRegex *regexp.Regexp
+}
+
+/*
+Args:
+	port: The port on the service to send the request to
+	urlPath: The path of the URL to request, e.g. "/health"
+	expectedStatusCode: The status code that indicates the service is available
+	bodyRegex: If non-nil, a regex that the response body must match for the service to be considered available
+ */
+func NewHttpCheck(port int, urlPath string, expectedStatusCode int, bodyRegex *regexp.Regexp) *HttpCheck {
+	return &HttpCheck{
+		port:               port,
+		urlPath:            "/" + strings.TrimPrefix(urlPath, "/"),
+		expectedStatusCode: expectedStatusCode,
+		bodyRegex:          bodyRegex,
+	}
+}
+
+func (check HttpCheck) Check(ctx context.Context, service Service, containerAccessor ContainerAccessor) error {
+	url := fmt.Sprintf("http://%v:%v%v", service.GetIPAddress(), check.port, check.urlPath)
+	probeCtx, cancelFunc := context.WithTimeout(ctx, probeTimeout)
+	defer cancelFunc()
+	req, err := http.NewRequestWithContext(probeCtx, http.MethodGet, url, nil)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating a request to '%v'", url)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred making a request to '%v'", url)
+	}
+	body := resp.Body
+	defer body.Close()
+
+	if resp.StatusCode != check.expectedStatusCode {
+		return stacktrace.NewError("Expected status code %v from '%v' but got %v", check.expectedStatusCode, url, resp.StatusCode)
+	}
+
+	if check.bodyRegex == nil {
+		return nil
+	}
+	bodyBytes, err := ioutil.ReadAll(body)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred reading the response body from '%v'", url)
+	}
+	if !check.bodyRegex.Match(bodyBytes) {
+		return stacktrace.NewError("Response body from '%v' didn't match regex '%v'", url, check.bodyRegex.String())
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                            TCP check
+// ====================================================================================================
+/*
+Checks that the service is accepting TCP connections on a port
+ */
+type TcpCheck struct {
+	port int
+}
+
+func NewTcpCheck(port int) *TcpCheck {
+	return &TcpCheck{port: port}
+}
+
+func (check TcpCheck) Check(ctx context.Context, service Service, containerAccessor ContainerAccessor) error {
+	address := net.JoinHostPort(service.GetIPAddress(), fmt.Sprintf("%v", check.port))
+	dialer := net.Dialer{Timeout: probeTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred opening a TCP connection to '%v'", address)
+	}
+	conn.Close()
+	return nil
+}
+
+// ====================================================================================================
+//                                         gRPC health check
+// ====================================================================================================
+/*
+Checks that the service reports SERVING via the standard gRPC health checking protocol
+	(https://github.com/grpc/grpc/blob/master/doc/health-checking.md)
+ */
+type GrpcHealthCheck struct {
+	port int
+	// The gRPC service name to check the health of; empty checks the health of the server as a whole
+	grpcServiceName string
+}
+
+func NewGrpcHealthCheck(port int, grpcServiceName string) *GrpcHealthCheck {
+	return &GrpcHealthCheck{port: port, grpcServiceName: grpcServiceName}
+}
+
+func (check GrpcHealthCheck) Check(ctx context.Context, service Service, containerAccessor ContainerAccessor) error {
+	probeCtx, cancelFunc := context.WithTimeout(ctx, probeTimeout)
+	defer cancelFunc()
+
+	address := net.JoinHostPort(service.GetIPAddress(), fmt.Sprintf("%v", check.port))
+	conn, err := grpc.DialContext(probeCtx, address, grpc.WithInsecure(), grpc.WithBlock())
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred connecting to the gRPC server at '%v'", address)
+	}
+	defer conn.Close()
+
+	healthClient := grpc_health_v1.NewHealthClient(conn)
+	resp, err := healthClient.Check(probeCtx, &grpc_health_v1.HealthCheckRequest{Service: check.grpcServiceName})
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred calling the gRPC health endpoint at '%v'", address)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return stacktrace.NewError("gRPC server at '%v' reported status '%v'", address, resp.Status)
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                           Exec check
+// ====================================================================================================
+/*
+Checks that a command run inside the service's container exits successfully
+ */
+type ExecCheck struct {
+	command []string
+}
+
+func NewExecCheck(command []string) *ExecCheck {
+	return &ExecCheck{command: command}
+}
+
+func (check ExecCheck) Check(ctx context.Context, service Service, containerAccessor ContainerAccessor) error {
+	if containerAccessor == nil {
+		return stacktrace.NewError("Command '%v' can't be executed because no container accessor was provided for the service", check.command)
+	}
+	exitCode, output, err := containerAccessor.ExecCommand(ctx, check.command)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred executing command '%v'", check.command)
+	}
+	if exitCode != successExitCode {
+		return stacktrace.NewError("Command '%v' exited with code %v and output: %v", check.command, exitCode, string(output))
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                          Log line check
+// ====================================================================================================
+/*
+Checks that the service's container has output a log line matching a regex (e.g. "Server started on port \d+")
+ */
+type LogLineCheck struct {
+	logLineRegex *regexp.Regexp
+}
+
+func NewLogLineCheck(logLineRegex *regexp.Regexp) *LogLineCheck {
+	return &LogLineCheck{logLineRegex: logLineRegex}
+}
+
+func (check LogLineCheck) Check(ctx context.Context, service Service, containerAccessor ContainerAccessor) error {
+	if containerAccessor == nil {
+		return stacktrace.NewError("The service's logs can't be read because no container accessor was provided for the service")
+	}
+	logs, err := containerAccessor.GetLogs(ctx)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the service's logs")
+	}
+	for _, line := range strings.Split(string(logs), "\n") {
+		if check.logLineRegex.MatchString(line) {
+			return nil
+		}
+	}
+	return stacktrace.NewError("No log line matching regex '%v' has been output yet", check.logLineRegex.String())
+}
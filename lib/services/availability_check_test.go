@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	localhostIpAddr = "127.0.0.1"
+	healthyBody = "healthy"
+
+	testGrpcServiceName = "example.Datastore"
+)
+
+type mockContainerAccessor struct {
+	execExitCode int32
+	logs string
+}
+
+func (accessor mockContainerAccessor) ExecCommand(ctx context.Context, command []string) (int32, []byte, error) {
+	return accessor.execExitCode, []byte{}, nil
+}
+
+func (accessor mockContainerAccessor) GetLogs(ctx context.Context) ([]byte, error) {
+	return []byte(accessor.logs), nil
+}
+
+// Blocks until the context is done, like a container that never responds
+type blockingContainerAccessor struct{}
+
+func (accessor blockingContainerAccessor) ExecCommand(ctx context.Context, command []string) (int32, []byte, error) {
+	<-ctx.Done()
+	return 0, nil, ctx.Err()
+}
+
+func (accessor blockingContainerAccessor) GetLogs(ctx context.Context) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestHttpCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/health" {
+			writer.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(writer, healthyBody)
+	}))
+	defer server.Close()
+	port := getListenerPort(t, server.Listener)
+	service := NewMockService(testServiceId, localhostIpAddr, 1)
+
+	assert.NoError(t, NewHttpCheck(port, "health", http.StatusOK, nil).Check(context.Background(), service, nil))
+	assert.NoError(t, NewHttpCheck(port, "/health", http.StatusOK, regexp.MustCompile("^healthy$")).Check(context.Background(), service, nil))
+	assert.Error(t, NewHttpCheck(port, "/health", http.StatusOK, regexp.MustCompile("^unhealthy$")).Check(context.Background(), service, nil))
+	assert.Error(t, NewHttpCheck(port, "/nonexistent", http.StatusOK, nil).Check(context.Background(), service, nil))
+}
+
+func TestTcpCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", localhostIpAddr + ":0")
+	if err != nil {
+		t.Fatalf("An error occurred opening a TCP listener: %v", err)
+	}
+	port := getListenerPort(t, listener)
+	service := NewMockService(testServiceId, localhostIpAddr, 1)
+
+	assert.NoError(t, NewTcpCheck(port).Check(context.Background(), service, nil))
+
+	listener.Close()
+	assert.Error(t, NewTcpCheck(port).Check(context.Background(), service, nil))
+}
+
+func TestGrpcHealthCheck(t *testing.T) {
+	listener, err := net.Listen("tcp", localhostIpAddr + ":0")
+	if err != nil {
+		t.Fatalf("An error occurred opening a TCP listener: %v", err)
+	}
+	port := getListenerPort(t, listener)
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(testGrpcServiceName, grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	grpcServer := grpc.NewServer()
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Stop()
+	service := NewMockService(testServiceId, localhostIpAddr, 1)
+
+	// The health server reports the server as a whole as SERVING by default
+	assert.NoError(t, NewGrpcHealthCheck(port, "").Check(context.Background(), service, nil))
+	assert.Error(t, NewGrpcHealthCheck(port, testGrpcServiceName).Check(context.Background(), service, nil))
+	assert.Error(t, NewGrpcHealthCheck(port, "example.Unknown").Check(context.Background(), service, nil))
+
+	healthServer.SetServingStatus(testGrpcServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+	assert.NoError(t, NewGrpcHealthCheck(port, testGrpcServiceName).Check(context.Background(), service, nil))
+
+	// The blocking dial never succeeds against a stopped server, so the check must give up when the context is done
+	grpcServer.Stop()
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 200 * time.Millisecond)
+	defer cancelFunc()
+	checkStartTime := time.Now()
+	assert.Error(t, NewGrpcHealthCheck(port, "").Check(ctx, service, nil))
+	assert.True(t, time.Since(checkStartTime) < probeTimeout, "Expected the check to give up when the context was done")
+}
+
+func TestExecCheck(t *testing.T) {
+	service := NewMockService(testServiceId, localhostIpAddr, 1)
+	check := NewExecCheck([]string{"true"})
+
+	assert.NoError(t, check.Check(context.Background(), service, mockContainerAccessor{execExitCode: 0}))
+	assert.Error(t, check.Check(context.Background(), service, mockContainerAccessor{execExitCode: 1}))
+	assert.Error(t, check.Check(context.Background(), service, nil))
+}
+
+func TestLogLineCheck(t *testing.T) {
+	service := NewMockService(testServiceId, localhostIpAddr, 1)
+	check := NewLogLineCheck(regexp.MustCompile(`^Server started on port \d+$`))
+
+	assert.NoError(t, check.Check(context.Background(), service, mockContainerAccessor{logs: "Initializing...\nServer started on port 8080\n"}))
+	assert.Error(t, check.Check(context.Background(), service, mockContainerAccessor{logs: "Initializing...\n"}))
+	assert.Error(t, check.Check(context.Background(), service, nil))
+}
+
+func getListenerPort(t *testing.T, listener net.Listener) int {
+	_, portStr, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		t.Fatalf("An error occurred splitting the listener address: %v", err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatalf("An error occurred parsing port string '%v': %v", portStr, err)
+	}
+	return port
+}
+
+func TestContainerAccessorChecksAreCancelledByContext(t *testing.T) {
+	service := NewMockService(testServiceId, localhostIpAddr, 1)
+	checks := []AvailabilityCheck{
+		NewExecCheck([]string{"true"}),
+		NewLogLineCheck(regexp.MustCompile("started")),
+	}
+	for _, check := range checks {
+		ctx, cancelFunc := context.WithTimeout(context.Background(), 50 * time.Millisecond)
+		checkErrChan := make(chan error, 1)
+		go func() {
+			checkErrChan <- check.Check(ctx, service, blockingContainerAccessor{})
+		}()
+		select {
+		case err := <-checkErrChan:
+			assert.Error(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Check %T wasn't cancelled by its context", check)
+		}
+		cancelFunc()
+	}
+}
@@ -6,12 +6,25 @@
 package services
 
 import (
+	"context"
 	"github.com/palantir/stacktrace"
 	"time"
 )
 
 type AvailabilityChecker interface {
 	WaitForStartup(timeBetweenPolls time.Duration, maxNumRetries int) error
+
+	/*
+	Polls the service until it's available, waiting between polls according to the given backoff strategy, until the
+		context is cancelled or hits its deadline. To wait for e.g. at most 90 seconds, pass in a context created with
+		context.WithTimeout.
+	If the service doesn't become available, the returned error contains the reason the last check failed.
+	 */
+	WaitForStartupWithContext(ctx context.Context, backoff BackoffStrategy) error
+
+	// Checks the service's availability exactly once, returning nil if it's available or an error describing why not;
+	//  the check is cancelled when the context is done
+	CheckAvailability(ctx context.Context) error
 }
 
 /*
@@ -23,10 +36,35 @@ type DefaultAvailabilityChecker struct {
 
 	// The service being monitored
 	toCheck Service
+
+	// The strategy used to determine whether the service is available
+	check AvailabilityCheck
+
+	// Access to the service's container, for checks that need it
+	containerAccessor ContainerAccessor
 }
 
+/*
+Creates a checker that determines availability using the service's own IsAvailable method
+ */
 func NewDefaultAvailabilityChecker(serviceId ServiceID, toCheck Service) *DefaultAvailabilityChecker {
-	return &DefaultAvailabilityChecker{serviceId: serviceId, toCheck: toCheck}
+	return NewAvailabilityCheckerWithCheck(serviceId, toCheck, NewIsAvailableCheck(), nil)
+}
+
+/*
+Creates a checker that determines availability using the given check strategy
+ */
+func NewAvailabilityCheckerWithCheck(
+		serviceId ServiceID,
+		toCheck Service,
+		check AvailabilityCheck,
+		containerAccessor ContainerAccessor) *DefaultAvailabilityChecker {
+	return &DefaultAvailabilityChecker{
+		serviceId:         serviceId,
+		toCheck:           toCheck,
+		check:             check,
+		containerAccessor: containerAccessor,
+	}
 }
 
 /*
@@ -34,8 +72,10 @@ Waits for the service that was passed in at construction time to start up by mak
 	the service is available or the maximum number of retries are reached
  */
 func (checker DefaultAvailabilityChecker) WaitForStartup(timeBetweenPolls time.Duration, maxNumRetries int) error {
+	var lastCheckErr error
 	for i := 0; i < maxNumRetries; i++ {
-		if checker.toCheck.IsAvailable() {
+		lastCheckErr = checker.check.Check(context.Background(), checker.toCheck, checker.containerAccessor)
+		if lastCheckErr == nil {
 			return nil
 		}
 
@@ -44,9 +84,62 @@ func (checker DefaultAvailabilityChecker) WaitForStartup(timeBetweenPolls time.D
 			time.Sleep(timeBetweenPolls)
 		}
 	}
-	return stacktrace.NewError(
+	if lastCheckErr == nil {
+		return stacktrace.NewError("Service '%v' was never polled because the max number of retries was %v", checker.serviceId, maxNumRetries)
+	}
+	return stacktrace.Propagate(
+		lastCheckErr,
 		"Service '%v' did not become available despite polling %v times with %v between polls",
 		checker.serviceId,
 		maxNumRetries,
 		timeBetweenPolls)
 }
+
+/*
+Waits for the service that was passed in at construction time to start up by making requests to the service until
+	the service is available or the context is done
+ */
+func (checker DefaultAvailabilityChecker) WaitForStartupWithContext(ctx context.Context, backoff BackoffStrategy) error {
+	var lastCheckErr error
+	numFailedChecks := 0
+	for {
+		// The check is given the context so that a slow probe (e.g. a network dial timing out) is cancelled when it's done
+		checkErr := checker.check.Check(ctx, checker.toCheck, checker.containerAccessor)
+		if checkErr == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return checker.newContextDoneError(ctx, numFailedChecks, lastCheckErr)
+		}
+		lastCheckErr = checkErr
+		numFailedChecks++
+
+		select {
+		case <- time.After(backoff.GetDelay(numFailedChecks)):
+		case <- ctx.Done():
+			return checker.newContextDoneError(ctx, numFailedChecks, lastCheckErr)
+		}
+	}
+}
+
+func (checker DefaultAvailabilityChecker) newContextDoneError(ctx context.Context, numFailedChecks int, lastCheckErr error) error {
+	if lastCheckErr == nil {
+		return stacktrace.Propagate(
+			ctx.Err(),
+			"Service '%v' did not become available because the context was done before the first check completed",
+			checker.serviceId)
+	}
+	return stacktrace.Propagate(
+		lastCheckErr,
+		"Service '%v' did not become available after %v failed checks before the context was done (%v); the last check failed with the following error",
+		checker.serviceId,
+		numFailedChecks,
+		ctx.Err())
+}
+
+func (checker DefaultAvailabilityChecker) CheckAvailability(ctx context.Context) error {
+	if err := checker.check.Check(ctx, checker.toCheck, checker.containerAccessor); err != nil {
+		return stacktrace.Propagate(err, "Service '%v' is not available", checker.serviceId)
+	}
+	return nil
+}
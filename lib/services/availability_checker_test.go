@@ -6,6 +6,9 @@
 package services
 
 import (
+	"context"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
 )
@@ -14,6 +17,18 @@ const (
 	testServiceId ServiceID = "test-service"
 )
 
+// A check that blocks until its context is done, like a network probe against an unresponsive service
+type blockingCheck struct {
+	// Receives the context's error once the check has been cancelled
+	cancelledErrs chan error
+}
+
+func (check blockingCheck) Check(ctx context.Context, service Service, containerAccessor ContainerAccessor) error {
+	<- ctx.Done()
+	check.cancelledErrs <- ctx.Err()
+	return stacktrace.Propagate(ctx.Err(), "The check was cancelled")
+}
+
 func TestServiceBecomesAvailable(t *testing.T) {
 	service := NewMockService("mock-service", "1.2.3.4", 2)
 	availabilityChecker := NewDefaultAvailabilityChecker(testServiceId, service)
@@ -31,3 +46,49 @@ func TestTimeoutOnServiceStartup(t *testing.T) {
 		t.Fatalf("Expected an error waiting for a never-available service, but no error was thrown")
 	}
 }
+
+func TestServiceBecomesAvailableWithContext(t *testing.T) {
+	service := NewMockService("mock-service", "1.2.3.4", 3)
+	availabilityChecker := NewDefaultAvailabilityChecker(testServiceId, service)
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 5 * time.Second)
+	defer cancelFunc()
+	backoff := NewExponentialBackoff(10 * time.Millisecond, 2, 100 * time.Millisecond, 0.1)
+	if err := availabilityChecker.WaitForStartupWithContext(ctx, backoff); err != nil {
+		t.Fatalf("Expected service to become available successfully but an error was thrown: %v", err)
+	}
+}
+
+func TestDeadlineOnServiceStartupWithContext(t *testing.T) {
+	neverAvailableService := NewMockService("mock-service", "1.2.3.4", 9999)
+	availabilityChecker := NewDefaultAvailabilityChecker(testServiceId, neverAvailableService)
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 300 * time.Millisecond)
+	defer cancelFunc()
+	err := availabilityChecker.WaitForStartupWithContext(ctx, NewConstantBackoff(50 * time.Millisecond))
+	if err == nil {
+		t.Fatalf("Expected an error waiting for a never-available service, but no error was thrown")
+	}
+	// The error should explain why the service wasn't available, not just that time ran out
+	assert.Contains(t, err.Error(), "IsAvailable method returned false")
+}
+
+func TestWaitForStartupWithContextCancelsCheck(t *testing.T) {
+	service := NewMockService("mock-service", "1.2.3.4", 9999)
+	check := blockingCheck{cancelledErrs: make(chan error, 1)}
+	availabilityChecker := NewAvailabilityCheckerWithCheck(testServiceId, service, check, nil)
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 100 * time.Millisecond)
+	defer cancelFunc()
+	err := availabilityChecker.WaitForStartupWithContext(ctx, NewConstantBackoff(50 * time.Millisecond))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "context was done before the first check completed")
+
+	// The in-flight check should have been cancelled rather than left running
+	select {
+	case cancelledErr := <- check.cancelledErrs:
+		assert.Equal(t, context.DeadlineExceeded, cancelledErr)
+	default:
+		t.Fatal("Expected the in-flight check to have been cancelled when the context was done")
+	}
+}
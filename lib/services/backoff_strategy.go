@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+/*
+Determines how long to wait between successive polls of a service's availability
+ */
+type BackoffStrategy interface {
+	/*
+	Args:
+		numFailedChecks: The number of checks that have failed so far, which will always be >= 1
+
+	Returns:
+		How long to wait before the next check
+	 */
+	GetDelay(numFailedChecks int) time.Duration
+}
+
+// ====================================================================================================
+//                                          Constant backoff
+// ====================================================================================================
+/*
+Waits the same amount of time between every poll
+ */
+type ConstantBackoff struct {
+	delay time.Duration
+}
+
+func NewConstantBackoff(delay time.Duration) *ConstantBackoff {
+	return &ConstantBackoff{delay: delay}
+}
+
+func (backoff ConstantBackoff) GetDelay(numFailedChecks int) time.Duration {
+	return backoff.delay
+}
+
+// ====================================================================================================
+//                                         Exponential backoff
+// ====================================================================================================
+/*
+Multiplies the wait between polls after every failed check, up to a cap, with optional random jitter so that
+	many checkers started at the same time don't poll in lockstep
+ */
+type ExponentialBackoff struct {
+	initialDelay time.Duration
+	multiplier float64
+	maxDelay time.Duration
+	jitterFraction float64
+}
+
+/*
+Args:
+	initialDelay: The delay after the first failed check
+	multiplier: The factor the delay is multiplied by after each subsequent failed check (e.g. 2 to double it)
+	maxDelay: The cap on the delay, before jitter is applied
+	jitterFraction: Between 0 and 1; the delay will be randomly adjusted by up to +/- this fraction of itself (e.g. 0.2
+		means the delay will be between 80% and 120% of the un-jittered delay). Use 0 for no jitter.
+ */
+func NewExponentialBackoff(initialDelay time.Duration, multiplier float64, maxDelay time.Duration, jitterFraction float64) *ExponentialBackoff {
+	return &ExponentialBackoff{
+		initialDelay:   initialDelay,
+		multiplier:     multiplier,
+		maxDelay:       maxDelay,
+		jitterFraction: math.Max(0, math.Min(1, jitterFraction)),
+	}
+}
+
+func (backoff ExponentialBackoff) GetDelay(numFailedChecks int) time.Duration {
+	exponent := float64(numFailedChecks - 1)
+	if exponent < 0 {
+		exponent = 0
+	}
+	unjitteredDelay := math.Min(
+		float64(backoff.initialDelay) * math.Pow(backoff.multiplier, exponent),
+		float64(backoff.maxDelay))
+
+	// Random value in the range [-jitterFraction, jitterFraction)
+	jitterMultiplier := (rand.Float64() * 2 - 1) * backoff.jitterFraction
+	return time.Duration(unjitteredDelay * (1 + jitterMultiplier))
+}
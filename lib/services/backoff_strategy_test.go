@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConstantBackoff(t *testing.T) {
+	backoff := NewConstantBackoff(time.Second)
+	assert.Equal(t, time.Second, backoff.GetDelay(1))
+	assert.Equal(t, time.Second, backoff.GetDelay(10))
+}
+
+func TestExponentialBackoffIsCapped(t *testing.T) {
+	backoff := NewExponentialBackoff(100 * time.Millisecond, 2, time.Second, 0)
+	assert.Equal(t, 100 * time.Millisecond, backoff.GetDelay(1))
+	assert.Equal(t, 200 * time.Millisecond, backoff.GetDelay(2))
+	assert.Equal(t, 400 * time.Millisecond, backoff.GetDelay(3))
+	assert.Equal(t, time.Second, backoff.GetDelay(5))
+	assert.Equal(t, time.Second, backoff.GetDelay(100))
+}
+
+func TestExponentialBackoffJitterStaysInBounds(t *testing.T) {
+	backoff := NewExponentialBackoff(time.Second, 2, time.Second, 0.2)
+	for i := 0; i < 100; i++ {
+		delay := backoff.GetDelay(1)
+		assert.True(t, delay >= 800 * time.Millisecond && delay <= 1200 * time.Millisecond, "Delay %v was out of bounds", delay)
+	}
+}
@@ -12,32 +12,47 @@ import (
 // The ID of an artifact containing files that should be mounted into a service container
 type FilesArtifactID string
 
-// TODO Create a DockerContainerInitializerBuilder rather than forcing users to update their code with a new
-//  method every time a new feature comes out!
-// GENERIC TOOD: If Go had generics, this would be parameterized with the subtype of Service that this returns
-type DockerContainerInitializer interface {
-	// Gets the Docker image that will be used for instantiating the Docker container
-	GetDockerImage() string
+// The ID of a volume that's created for the test and can be mounted into multiple service containers
+type SharedVolumeID string
 
-	// Gets the "set" of ports that the Docker container running the service will listen on
-	// This is in Docker port specification syntax, e.g. "80" (default TCP) or "80/udp"
-	// It might even support ranges (e.g. "90:100/tcp"), though this is untested as of 2020-12-08
-	GetUsedPorts() map[string]bool
+/*
+The logic for starting a service's Docker container and creating the interface the developer has created to represent
+	their service. Prefer TypedDockerContainerInitializer, whose GetService returns the concrete service type so that
+	callers don't need to cast it, and consider DockerContainerInitializerBuilder for simple containers.
+ */
+type DockerContainerInitializer interface {
+	DockerContainerInitializerCore
 
-	// GENERICS TOOD: When Go has generics, make this return type be parameterized
 	/*
 		Uses the IP address of the Docker container running the service and the service ID to create an implementation of
 		the interface the developer has created to represent their service.
 
-		NOTE: Because Go doesn't have generics, we can't properly parameterize the return type to be the actual service interface
-		that the developer has created; nonetheless, the developer should return an implementation of their interface (which itself
-		should extend Service).
+		NOTE: Because this interface isn't parameterized, the return type can't be the actual service interface that the
+		developer has created; nonetheless, the developer should return an implementation of their interface (which itself
+		should extend Service). Use TypedDockerContainerInitializer to avoid this.
 
 		Args:
 			serviceId: The ID of the service being created
 			ipAddr: The IP address of the Docker container running the service
 	*/
 	GetService(serviceId ServiceID, ipAddr string) Service
+}
+
+/*
+The methods of a container initializer that don't depend on the type of service being created, which are shared by
+	DockerContainerInitializer and TypedDockerContainerInitializer
+
+NOTE: Features that need more from an initializer are declared with the optional interfaces below (e.g.
+	ResourceLimitsInitializer) rather than by adding methods here, so that existing initializers don't need to change
+	every time a new feature comes out
+ */
+type DockerContainerInitializerCore interface {
+	// Gets the Docker image that will be used for instantiating the Docker container
+	GetDockerImage() string
+
+	// Gets the ports that the Docker container running the service will listen on, which are exposed on the service's
+	//  ServiceInfo; naming them lets clients look them up by name via NetworkContext.GetServicePort
+	GetUsedPorts() []Port
 
 	// GENERICS TOOD: If Go had generics, we could parameterize this entire class with an enum of the types of files this service consumes
 	/*
@@ -61,6 +76,9 @@ type DockerContainerInitializer interface {
 		Initializes the contents of the files that the developer requested in `GetFilesToMount` with whatever
 			contents the developer desires. This will be called before service startup.
 
+		The files start out empty with mode 0644; an initializer that needs other permissions (e.g. for a script or a
+			key file) can change them with File.Chmod.
+
 		Args:
 			mountedFiles: A mapping of developer_key -> file_pointer, with developer_key corresponding to the keys declares in
 				`GetFilesToMount`
@@ -72,11 +90,13 @@ type DockerContainerInitializer interface {
 		Allows the mounting of external files into a service container by mapping files artifacts (defined in your
 		test's configuration) to mountpoints on the service container.
 
-		NOTE: As of 2021-01-06, only GZ-compressed TAR artifacts are supported.
+		NOTE: As of 2021-01-06, only GZ-compressed TAR artifacts are supported for URL artifacts. Local artifacts can
+			also be directories or uncompressed TARs, which are packed onto the suite execution volume when first mounted.
 
 		Returns:
 			A map of filesArtifactId -> serviceContainerMountpoint, where:
-				1) The map key is the ID of the files artifact as defined in your TestConfiguration.
+				1) The map key is the ID of the files artifact as defined in your TestConfiguration's FilesArtifactUrls
+					or LocalFilesArtifacts.
 				2) The map value is the filepath inside of the service container where the
 					contents of the archive file should be mounted after decompression.
 	 */
@@ -100,6 +120,10 @@ type DockerContainerInitializer interface {
 			container should use the placeholder "SERVICEIP" instead. This will get replaced at launch time with the service's
 			actual IP.
 
+		Arguments can also refer to other services in the network with "${service:<service ID>:ip}" and
+			"${service:<service ID>:port:<port name>}", which are resolved when the service is started and fail the start if
+			the service or port doesn't exist. The same references can be used in env vars and file templates.
+
 		Args:
 			mountedFileFilepaths: Mapping of developer_key -> initialized_file_filepath where developer_key corresponds to the keys returned
 				in the `GetFilesToMount` function, and initialized_file_filepath is the path *on the Docker container* of where the
@@ -113,3 +137,148 @@ type DockerContainerInitializer interface {
 	*/
 	GetStartCommand(mountedFileFilepaths map[string]string, ipAddr string) ([]string, error)
 }
+
+// ====================================================================================================
+//                                     Optional initializer methods
+// ====================================================================================================
+/*
+Implemented by initializers that constrain the resources their service's container can use
+ */
+type ResourceLimitsInitializer interface {
+	/*
+		Declares the constraints on the resources that the Docker container running the service can use. These can be
+		changed after the service has started via NetworkContext.UpdateServiceResourceLimits.
+
+		Returns:
+			The resource limits for the service's container; the zero value places no constraints on the container
+	 */
+	GetResourceLimits() ResourceLimits
+}
+
+// Gets the resource limits declared by the given initializer, or no limits if it isn't a ResourceLimitsInitializer
+func GetInitializerResourceLimits(initializer interface{}) ResourceLimits {
+	if resourceLimitsInitializer, ok := initializer.(ResourceLimitsInitializer); ok {
+		return resourceLimitsInitializer.GetResourceLimits()
+	}
+	return ResourceLimits{}
+}
+
+/*
+Implemented by initializers that select how their service's availability is checked, rather than relying on the
+	service's own `IsAvailable` method
+ */
+type AvailabilityCheckInitializer interface {
+	/*
+		Selects the strategy used by the service's AvailabilityChecker to determine whether the service is available, e.g.
+		an HTTP, TCP, gRPC health, command, or log line check (see availability_check.go).
+
+		Returns:
+			The availability check to use, or nil to fall back to the service's own `IsAvailable` method
+	 */
+	GetAvailabilityCheck() AvailabilityCheck
+}
+
+// Gets the availability check selected by the given initializer, which is an IsAvailableCheck if it isn't an
+//  AvailabilityCheckInitializer or doesn't select one
+func GetInitializerAvailabilityCheck(initializer interface{}) AvailabilityCheck {
+	if availabilityCheckInitializer, ok := initializer.(AvailabilityCheckInitializer); ok {
+		if availabilityCheck := availabilityCheckInitializer.GetAvailabilityCheck(); availabilityCheck != nil {
+			return availabilityCheck
+		}
+	}
+	return NewIsAvailableCheck()
+}
+
+/*
+Implemented by initializers whose service needs other services to be available before it can be started
+ */
+type DependenciesInitializer interface {
+	/*
+		Declares the other services that this service needs to be available before it can be started. This is used by
+		NetworkContext.AddServicesInDependencyOrder to decide the order in which services are started.
+
+		Returns:
+			A "set" of the IDs of the services this service depends on, which may be empty
+	 */
+	GetDependencies() map[ServiceID]bool
+}
+
+// Gets the dependencies declared by the given initializer, or none if it isn't a DependenciesInitializer
+func GetInitializerDependencies(initializer interface{}) map[ServiceID]bool {
+	if dependenciesInitializer, ok := initializer.(DependenciesInitializer); ok {
+		return dependenciesInitializer.GetDependencies()
+	}
+	return map[ServiceID]bool{}
+}
+
+/*
+Implemented by initializers whose service needs files rendered from Go templates when it's started
+ */
+type FileTemplatesInitializer interface {
+	/*
+		Declares files whose contents are Go templates that Kurtosis renders when the service is started, with access to
+		the service's own IP address, the IPs & ports of the other services in the network, and the template's own values
+		(see FileTemplateData). These are created alongside the files declared in `GetFilesToMount`, so their keys must
+		not overlap, and their paths are passed to `GetStartCommand` in the same way.
+
+		Returns:
+			A map of developer_key -> template, which may be empty
+	 */
+	GetFileTemplates() map[string]*FileTemplate
+}
+
+// Gets the file templates declared by the given initializer, or none if it isn't a FileTemplatesInitializer
+func GetInitializerFileTemplates(initializer interface{}) map[string]*FileTemplate {
+	if fileTemplatesInitializer, ok := initializer.(FileTemplatesInitializer); ok {
+		return fileTemplatesInitializer.GetFileTemplates()
+	}
+	return map[string]*FileTemplate{}
+}
+
+/*
+Implemented by initializers (of services or jobs) whose container mounts shared volumes
+ */
+type SharedVolumesInitializer interface {
+	/*
+		Mounts writable volumes that are shared between every service (and job) that mounts a volume with the same ID, e.g.
+		a keystore, or a genesis file produced by one node and consumed by the others. Each volume is created the first
+		time it's needed in a test, and the testsuite can read & write its contents via
+		NetworkContext.GetSharedVolumeDirpath.
+
+		Returns:
+			A map of sharedVolumeId -> serviceContainerMountpoint, which may be empty
+	 */
+	GetSharedVolumeMountpoints() map[SharedVolumeID]string
+}
+
+// Gets the shared volume mountpoints declared by the given initializer, or none if it isn't a SharedVolumesInitializer
+func GetInitializerSharedVolumeMountpoints(initializer interface{}) map[SharedVolumeID]string {
+	if sharedVolumesInitializer, ok := initializer.(SharedVolumesInitializer); ok {
+		return sharedVolumesInitializer.GetSharedVolumeMountpoints()
+	}
+	return map[SharedVolumeID]string{}
+}
+
+/*
+Implemented by initializers whose service needs files & directories created with explicit permissions or contents
+ */
+type GeneratedFilesInitializer interface {
+	/*
+		Declares files & directories that Kurtosis creates for the service with explicit permissions, from in-memory
+		(possibly binary) contents or from paths in the testsuite's image (see GeneratedFile), e.g. a directory tree of
+		config, an executable script, or a key file that must only be readable by its owner. As with templated files,
+		their keys must not overlap the other generated files' keys, and their paths are passed to `GetStartCommand`.
+
+		Returns:
+			A map of developer_key -> generated file, which may be empty
+	 */
+	GetGeneratedFiles() map[string]*GeneratedFile
+}
+
+// Gets the generated files declared by the given initializer, or none if it isn't a GeneratedFilesInitializer
+func GetInitializerGeneratedFiles(initializer interface{}) map[string]*GeneratedFile {
+	if generatedFilesInitializer, ok := initializer.(GeneratedFilesInitializer); ok {
+		return generatedFilesInitializer.GetGeneratedFiles()
+	}
+	return map[string]*GeneratedFile{}
+}
@@ -0,0 +1,402 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"os"
+	"path"
+	"strings"
+	"text/template"
+)
+
+/*
+Writes the contents of a generated file, given the file that Kurtosis created for it
+ */
+type FileContentGenerator func(fp *os.File) error
+
+/*
+The data available to the templates passed to DockerContainerInitializerBuilder.WithStartCommand, e.g.:
+
+	--bind={{.IPAddress}} --config={{index .GeneratedFilepaths "config"}}
+ */
+type StartCommandTemplateData struct {
+	// The IP address of the service's container
+	IPAddress string
+
+	// Mapping of generated file key -> the filepath of the generated file *on the service's container*
+	GeneratedFilepaths map[string]string
+}
+
+/*
+Declaratively builds a container initializer, so that simple containers don't need a type implementing every
+	DockerContainerInitializer method, e.g.:
+
+	initializer, err := services.NewDockerContainerInitializerBuilder[*MyService]().
+		WithDockerImage("my-image").
+		WithUsedPorts(services.Port{Number: 8080, Protocol: services.TCP, Name: "http"}).
+		WithTestVolumeMountpoint("/test-volume").
+		WithServiceFactory(NewMyService).
+		Build()
+
+The built initializer satisfies TypedDockerContainerInitializer[S], and a builder parameterized with Service produces
+	an initializer that satisfies DockerContainerInitializer directly.
+ */
+type DockerContainerInitializerBuilder[S Service] struct {
+	dockerImage string
+	usedPorts []Port
+	fileGenerators map[string]FileContentGenerator
+	fileTemplates map[string]*FileTemplate
+	generatedFiles map[string]*GeneratedFile
+	filesArtifactMountpoints map[FilesArtifactID]string
+	sharedVolumeMountpoints map[SharedVolumeID]string
+	testVolumeMountpoint string
+	// Nil means the image's default command will be used
+	startCommandTemplateStrs []string
+	serviceFactory func(serviceId ServiceID, ipAddr string) S
+	resourceLimits ResourceLimits
+	availabilityCheck AvailabilityCheck
+	dependencies map[ServiceID]bool
+
+	// Problems with the arguments passed to the builder's methods, which are reported by Build
+	argumentErrs []string
+}
+
+func NewDockerContainerInitializerBuilder[S Service]() *DockerContainerInitializerBuilder[S] {
+	return &DockerContainerInitializerBuilder[S]{
+		dockerImage:              "",
+		usedPorts:                []Port{},
+		fileGenerators:           map[string]FileContentGenerator{},
+		fileTemplates:            map[string]*FileTemplate{},
+		generatedFiles:           map[string]*GeneratedFile{},
+		filesArtifactMountpoints: map[FilesArtifactID]string{},
+		sharedVolumeMountpoints:  map[SharedVolumeID]string{},
+		testVolumeMountpoint:     "",
+		startCommandTemplateStrs: nil,
+		serviceFactory:           nil,
+		resourceLimits:           ResourceLimits{},
+		availabilityCheck:        nil,
+		dependencies:             map[ServiceID]bool{},
+		argumentErrs:             []string{},
+	}
+}
+
+// Required; see DockerContainerInitializerCore.GetDockerImage
+func (builder *DockerContainerInitializerBuilder[S]) WithDockerImage(dockerImage string) *DockerContainerInitializerBuilder[S] {
+	builder.dockerImage = dockerImage
+	return builder
+}
+
+// Adds ports that the container listens on
+func (builder *DockerContainerInitializerBuilder[S]) WithUsedPorts(ports ...Port) *DockerContainerInitializerBuilder[S] {
+	builder.usedPorts = append(builder.usedPorts, ports...)
+	return builder
+}
+
+/*
+Declares a file that Kurtosis will generate for the container, whose contents will be written by the given generator.
+	The file's path on the container is available to the start command as {{index .GeneratedFilepaths "<key>"}}.
+ */
+func (builder *DockerContainerInitializerBuilder[S]) WithGeneratedFile(key string, generator FileContentGenerator) *DockerContainerInitializerBuilder[S] {
+	builder.checkGeneratedFileKeyUnused(key)
+	if generator == nil {
+		builder.argumentErrs = append(builder.argumentErrs, fmt.Sprintf("Generated file '%v' has a nil content generator", key))
+	}
+	builder.fileGenerators[key] = generator
+	return builder
+}
+
+/*
+Declares a generated file whose contents are rendered from the given Go text/template when the service is started (see
+	FileTemplateData), with the given user-provided values available as {{.Values.<key>}} and the given mode (or 0 for
+	0644). As with WithGeneratedFile, the file's path on the container is available to the start command as
+	{{index .GeneratedFilepaths "<key>"}}.
+ */
+func (builder *DockerContainerInitializerBuilder[S]) WithTemplatedFile(
+		key string,
+		templateStr string,
+		values map[string]interface{},
+		mode os.FileMode) *DockerContainerInitializerBuilder[S] {
+	builder.checkGeneratedFileKeyUnused(key)
+	fileTemplate, err := NewFileTemplate(templateStr, values, mode)
+	if err != nil {
+		builder.argumentErrs = append(builder.argumentErrs, stacktrace.Propagate(err, "Templated file '%v' couldn't be parsed", key).Error())
+		return builder
+	}
+	builder.fileTemplates[key] = fileTemplate
+	return builder
+}
+
+/*
+Declares a file or directory that Kurtosis will create for the container with explicit permissions, from bytes or from
+	a path in the testsuite's image (see GeneratedFile). As with WithGeneratedFile, its path on the container is
+	available to the start command as {{index .GeneratedFilepaths "<key>"}}.
+ */
+func (builder *DockerContainerInitializerBuilder[S]) WithFile(key string, generatedFile *GeneratedFile) *DockerContainerInitializerBuilder[S] {
+	builder.checkGeneratedFileKeyUnused(key)
+	if generatedFile == nil {
+		builder.argumentErrs = append(builder.argumentErrs, fmt.Sprintf("Generated file '%v' is nil", key))
+	}
+	builder.generatedFiles[key] = generatedFile
+	return builder
+}
+
+// Mounts the contents of the given files artifact at the given directory in the container
+func (builder *DockerContainerInitializerBuilder[S]) WithFilesArtifactMount(filesArtifactId FilesArtifactID, mountDirpath string) *DockerContainerInitializerBuilder[S] {
+	if !path.IsAbs(mountDirpath) {
+		builder.argumentErrs = append(builder.argumentErrs, fmt.Sprintf("Mountpoint '%v' of files artifact '%v' isn't absolute", mountDirpath, filesArtifactId))
+	}
+	builder.filesArtifactMountpoints[filesArtifactId] = mountDirpath
+	return builder
+}
+
+// Mounts the shared volume with the given ID at the given directory in the container (see
+//  SharedVolumesInitializer.GetSharedVolumeMountpoints)
+func (builder *DockerContainerInitializerBuilder[S]) WithSharedVolumeMount(volumeId SharedVolumeID, mountDirpath string) *DockerContainerInitializerBuilder[S] {
+	if !path.IsAbs(mountDirpath) {
+		builder.argumentErrs = append(builder.argumentErrs, fmt.Sprintf("Mountpoint '%v' of shared volume '%v' isn't absolute", mountDirpath, volumeId))
+	}
+	builder.sharedVolumeMountpoints[volumeId] = mountDirpath
+	return builder
+}
+
+// Required; see DockerContainerInitializerCore.GetTestVolumeMountpoint
+func (builder *DockerContainerInitializerBuilder[S]) WithTestVolumeMountpoint(mountpoint string) *DockerContainerInitializerBuilder[S] {
+	builder.testVolumeMountpoint = mountpoint
+	return builder
+}
+
+/*
+Sets the command the container is started with, where each argument is a Go text/template rendered with
+	StartCommandTemplateData. If this isn't called, the container runs the command specified by its image.
+ */
+func (builder *DockerContainerInitializerBuilder[S]) WithStartCommand(argTemplates ...string) *DockerContainerInitializerBuilder[S] {
+	builder.startCommandTemplateStrs = argTemplates
+	return builder
+}
+
+// Required; creates the service from its ID and the IP of its container (see DockerContainerInitializer.GetService)
+func (builder *DockerContainerInitializerBuilder[S]) WithServiceFactory(factory func(serviceId ServiceID, ipAddr string) S) *DockerContainerInitializerBuilder[S] {
+	builder.serviceFactory = factory
+	return builder
+}
+
+func (builder *DockerContainerInitializerBuilder[S]) WithResourceLimits(resourceLimits ResourceLimits) *DockerContainerInitializerBuilder[S] {
+	builder.resourceLimits = resourceLimits
+	return builder
+}
+
+// If not called, the service's own IsAvailable method will be used
+func (builder *DockerContainerInitializerBuilder[S]) WithAvailabilityCheck(check AvailabilityCheck) *DockerContainerInitializerBuilder[S] {
+	builder.availabilityCheck = check
+	return builder
+}
+
+func (builder *DockerContainerInitializerBuilder[S]) WithDependencies(serviceIds ...ServiceID) *DockerContainerInitializerBuilder[S] {
+	for _, serviceId := range serviceIds {
+		builder.dependencies[serviceId] = true
+	}
+	return builder
+}
+
+/*
+Validates the builder's configuration and creates the initializer
+
+Returns:
+	The initializer, or an error listing every problem with the configuration (e.g. a missing required field or a
+		start command template that doesn't parse)
+ */
+func (builder *DockerContainerInitializerBuilder[S]) Build() (TypedDockerContainerInitializer[S], error) {
+	problems := append([]string{}, builder.argumentErrs...)
+	if strings.TrimSpace(builder.dockerImage) == "" {
+		problems = append(problems, "No Docker image was set")
+	}
+	if builder.testVolumeMountpoint == "" {
+		problems = append(problems, "No test volume mountpoint was set")
+	} else if !path.IsAbs(builder.testVolumeMountpoint) {
+		problems = append(problems, fmt.Sprintf("Test volume mountpoint '%v' isn't absolute", builder.testVolumeMountpoint))
+	}
+	if builder.serviceFactory == nil {
+		problems = append(problems, "No service factory was set")
+	}
+	if err := ValidatePorts(builder.usedPorts); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	var startCommandTemplates []*template.Template
+	if builder.startCommandTemplateStrs != nil {
+		startCommandTemplates = []*template.Template{}
+		for idx, templateStr := range builder.startCommandTemplateStrs {
+			argTemplate, err := template.New("").Option("missingkey=error").Parse(templateStr)
+			if err != nil {
+				problems = append(problems, stacktrace.Propagate(err, "Start command argument %v couldn't be parsed as a template", idx).Error())
+				continue
+			}
+			startCommandTemplates = append(startCommandTemplates, argTemplate)
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, stacktrace.NewError("The container initializer configuration is invalid:\n%v", strings.Join(problems, "\n"))
+	}
+
+	usedPorts := append([]Port{}, builder.usedPorts...)
+	fileGenerators := map[string]FileContentGenerator{}
+	for key, generator := range builder.fileGenerators {
+		fileGenerators[key] = generator
+	}
+	fileTemplates := map[string]*FileTemplate{}
+	for key, fileTemplate := range builder.fileTemplates {
+		fileTemplates[key] = fileTemplate
+	}
+	generatedFiles := map[string]*GeneratedFile{}
+	for key, generatedFile := range builder.generatedFiles {
+		generatedFiles[key] = generatedFile
+	}
+	filesArtifactMountpoints := map[FilesArtifactID]string{}
+	for filesArtifactId, mountpoint := range builder.filesArtifactMountpoints {
+		filesArtifactMountpoints[filesArtifactId] = mountpoint
+	}
+	sharedVolumeMountpoints := map[SharedVolumeID]string{}
+	for volumeId, mountpoint := range builder.sharedVolumeMountpoints {
+		sharedVolumeMountpoints[volumeId] = mountpoint
+	}
+	dependencies := map[ServiceID]bool{}
+	for serviceId := range builder.dependencies {
+		dependencies[serviceId] = true
+	}
+	return &builtDockerContainerInitializer[S]{
+		dockerImage:              builder.dockerImage,
+		usedPorts:                usedPorts,
+		fileGenerators:           fileGenerators,
+		fileTemplates:            fileTemplates,
+		generatedFiles:           generatedFiles,
+		filesArtifactMountpoints: filesArtifactMountpoints,
+		sharedVolumeMountpoints:  sharedVolumeMountpoints,
+		testVolumeMountpoint:     builder.testVolumeMountpoint,
+		startCommandTemplates:    startCommandTemplates,
+		serviceFactory:           builder.serviceFactory,
+		resourceLimits:           builder.resourceLimits,
+		availabilityCheck:        builder.availabilityCheck,
+		dependencies:             dependencies,
+	}, nil
+}
+
+func (builder *DockerContainerInitializerBuilder[S]) checkGeneratedFileKeyUnused(key string) {
+	_, isGeneratorKey := builder.fileGenerators[key]
+	_, isTemplateKey := builder.fileTemplates[key]
+	_, isGeneratedFileKey := builder.generatedFiles[key]
+	if isGeneratorKey || isTemplateKey || isGeneratedFileKey {
+		builder.argumentErrs = append(builder.argumentErrs, fmt.Sprintf("Generated file key '%v' was declared more than once", key))
+	}
+}
+
+// ====================================================================================================
+//                                       Built initializer
+// ====================================================================================================
+type builtDockerContainerInitializer[S Service] struct {
+	dockerImage string
+	usedPorts []Port
+	fileGenerators map[string]FileContentGenerator
+	fileTemplates map[string]*FileTemplate
+	generatedFiles map[string]*GeneratedFile
+	filesArtifactMountpoints map[FilesArtifactID]string
+	sharedVolumeMountpoints map[SharedVolumeID]string
+	testVolumeMountpoint string
+	// Nil means the image's default command will be used
+	startCommandTemplates []*template.Template
+	serviceFactory func(serviceId ServiceID, ipAddr string) S
+	resourceLimits ResourceLimits
+	availabilityCheck AvailabilityCheck
+	dependencies map[ServiceID]bool
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetDockerImage() string {
+	return initializer.dockerImage
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetUsedPorts() []Port {
+	return initializer.usedPorts
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetService(serviceId ServiceID, ipAddr string) S {
+	return initializer.serviceFactory(serviceId, ipAddr)
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetFilesToMount() map[string]bool {
+	result := map[string]bool{}
+	for key := range initializer.fileGenerators {
+		result[key] = true
+	}
+	return result
+}
+
+func (initializer builtDockerContainerInitializer[S]) InitializeMountedFiles(mountedFiles map[string]*os.File) error {
+	for key, generator := range initializer.fileGenerators {
+		fp, found := mountedFiles[key]
+		if !found {
+			return stacktrace.NewError("No file was created for generated file '%v'", key)
+		}
+		if err := generator(fp); err != nil {
+			return stacktrace.Propagate(err, "An error occurred generating the contents of file '%v'", key)
+		}
+	}
+	return nil
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetFileTemplates() map[string]*FileTemplate {
+	return initializer.fileTemplates
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetGeneratedFiles() map[string]*GeneratedFile {
+	return initializer.generatedFiles
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetFilesArtifactMountpoints() map[FilesArtifactID]string {
+	return initializer.filesArtifactMountpoints
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetSharedVolumeMountpoints() map[SharedVolumeID]string {
+	return initializer.sharedVolumeMountpoints
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetResourceLimits() ResourceLimits {
+	return initializer.resourceLimits
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetAvailabilityCheck() AvailabilityCheck {
+	return initializer.availabilityCheck
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetDependencies() map[ServiceID]bool {
+	return initializer.dependencies
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetTestVolumeMountpoint() string {
+	return initializer.testVolumeMountpoint
+}
+
+func (initializer builtDockerContainerInitializer[S]) GetStartCommand(mountedFileFilepaths map[string]string, ipAddr string) ([]string, error) {
+	if initializer.startCommandTemplates == nil {
+		return nil, nil
+	}
+
+	data := StartCommandTemplateData{
+		IPAddress:          ipAddr,
+		GeneratedFilepaths: mountedFileFilepaths,
+	}
+	result := []string{}
+	for idx, argTemplate := range initializer.startCommandTemplates {
+		argBuffer := &bytes.Buffer{}
+		if err := argTemplate.Execute(argBuffer, data); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred rendering start command argument %v", idx)
+		}
+		result = append(result, argBuffer.String())
+	}
+	return result, nil
+}
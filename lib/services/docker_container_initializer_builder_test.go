@@ -0,0 +1,205 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"bytes"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+const (
+	testBuilderImage = "test-image"
+	testBuilderVolumeMountpoint = "/test-volume"
+	testBuilderConfigFileKey = "config"
+	testBuilderConfigFileContents = "some config"
+)
+
+func newMockServiceForBuilder(serviceId ServiceID, ipAddr string) *MockService {
+	return NewMockService(serviceId, ipAddr, 1)
+}
+
+func TestBuilderBuildsInitializer(t *testing.T) {
+	initializer, err := NewDockerContainerInitializerBuilder[*MockService]().
+		WithDockerImage(testBuilderImage).
+		WithUsedPorts(Port{Number: 1000, Protocol: TCP, Name: "http"}, Port{Number: 2000, Protocol: UDP}).
+		WithGeneratedFile(testBuilderConfigFileKey, func(fp *os.File) error {
+			_, err := fp.WriteString(testBuilderConfigFileContents)
+			return err
+		}).
+		WithFile("key", NewGeneratedFileFromBytes([]byte("secret"), 0600)).
+		WithFilesArtifactMount("artifact", "/artifact").
+		WithSharedVolumeMount("keystore", "/keystore").
+		WithTestVolumeMountpoint(testBuilderVolumeMountpoint).
+		WithStartCommand("server", "--bind={{.IPAddress}}", "--config={{index .GeneratedFilepaths \"config\"}}").
+		WithServiceFactory(newMockServiceForBuilder).
+		WithDependencies("dependency").
+		Build()
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred building the initializer"))
+	}
+
+	assert.Equal(t, testBuilderImage, initializer.GetDockerImage())
+	assert.Equal(t, []Port{{Number: 1000, Protocol: TCP, Name: "http"}, {Number: 2000, Protocol: UDP}}, initializer.GetUsedPorts())
+	assert.Equal(t, map[string]bool{testBuilderConfigFileKey: true}, initializer.GetFilesToMount())
+	assert.Contains(t, GetInitializerGeneratedFiles(initializer), "key")
+	assert.Equal(t, map[FilesArtifactID]string{"artifact": "/artifact"}, initializer.GetFilesArtifactMountpoints())
+	assert.Equal(t, map[SharedVolumeID]string{"keystore": "/keystore"}, GetInitializerSharedVolumeMountpoints(initializer))
+	assert.Equal(t, testBuilderVolumeMountpoint, initializer.GetTestVolumeMountpoint())
+	assert.Equal(t, map[ServiceID]bool{"dependency": true}, GetInitializerDependencies(initializer))
+	assert.IsType(t, &IsAvailableCheck{}, GetInitializerAvailabilityCheck(initializer))
+
+	service := initializer.GetService("test-service", "1.2.3.4")
+	assert.Equal(t, ServiceID("test-service"), service.GetServiceID())
+	assert.Equal(t, "1.2.3.4", service.GetIPAddress())
+
+	startCmd, err := initializer.GetStartCommand(
+		map[string]string{testBuilderConfigFileKey: "/test-volume/config"},
+		"1.2.3.4")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred rendering the start command"))
+	}
+	assert.Equal(t, []string{"server", "--bind=1.2.3.4", "--config=/test-volume/config"}, startCmd)
+}
+
+func TestBuilderGeneratesFiles(t *testing.T) {
+	initializer, err := NewDockerContainerInitializerBuilder[*MockService]().
+		WithDockerImage(testBuilderImage).
+		WithGeneratedFile(testBuilderConfigFileKey, func(fp *os.File) error {
+			_, err := fp.WriteString(testBuilderConfigFileContents)
+			return err
+		}).
+		WithTestVolumeMountpoint(testBuilderVolumeMountpoint).
+		WithServiceFactory(newMockServiceForBuilder).
+		Build()
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred building the initializer"))
+	}
+
+	configFilepath := path.Join(t.TempDir(), testBuilderConfigFileKey)
+	configFp, err := os.Create(configFilepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the config file"))
+	}
+	defer configFp.Close()
+	if err := initializer.InitializeMountedFiles(map[string]*os.File{testBuilderConfigFileKey: configFp}); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred initializing the mounted files"))
+	}
+	contents, err := ioutil.ReadFile(configFilepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading the config file"))
+	}
+	assert.Equal(t, testBuilderConfigFileContents, string(contents))
+
+	// No start command was set, so the image's default command should be used
+	startCmd, err := initializer.GetStartCommand(map[string]string{}, "1.2.3.4")
+	assert.NoError(t, err)
+	assert.Nil(t, startCmd)
+}
+
+func TestBuilderTemplatedFiles(t *testing.T) {
+	initializer, err := NewDockerContainerInitializerBuilder[*MockService]().
+		WithDockerImage(testBuilderImage).
+		WithTemplatedFile(testBuilderConfigFileKey, "level={{.Values.level}}", map[string]interface{}{"level": "debug"}, 0).
+		WithTestVolumeMountpoint(testBuilderVolumeMountpoint).
+		WithServiceFactory(newMockServiceForBuilder).
+		Build()
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred building the initializer"))
+	}
+
+	// Templated files are rendered by the NetworkContext rather than the initializer
+	assert.Equal(t, map[string]bool{}, initializer.GetFilesToMount())
+	fileTemplates := GetInitializerFileTemplates(initializer)
+	assert.Equal(t, 1, len(fileTemplates))
+	buffer := &bytes.Buffer{}
+	if err := fileTemplates[testBuilderConfigFileKey].Render(buffer, FileTemplateData{}); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred rendering the templated file"))
+	}
+	assert.Equal(t, "level=debug", buffer.String())
+
+	_, err = NewDockerContainerInitializerBuilder[*MockService]().
+		WithDockerImage(testBuilderImage).
+		WithGeneratedFile(testBuilderConfigFileKey, func(fp *os.File) error { return nil }).
+		WithTemplatedFile(testBuilderConfigFileKey, "contents", nil, 0).
+		WithTemplatedFile("bad-template", "{{.Values", nil, 0).
+		WithTestVolumeMountpoint(testBuilderVolumeMountpoint).
+		WithServiceFactory(newMockServiceForBuilder).
+		Build()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Generated file key 'config' was declared more than once")
+	assert.Contains(t, err.Error(), "Templated file 'bad-template' couldn't be parsed")
+}
+
+func TestBuilderWithUntypedServiceIsDockerContainerInitializer(t *testing.T) {
+	initializer, err := NewDockerContainerInitializerBuilder[Service]().
+		WithDockerImage(testBuilderImage).
+		WithTestVolumeMountpoint(testBuilderVolumeMountpoint).
+		WithServiceFactory(func(serviceId ServiceID, ipAddr string) Service {
+			return newMockServiceForBuilder(serviceId, ipAddr)
+		}).
+		Build()
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred building the initializer"))
+	}
+
+	var untypedInitializer DockerContainerInitializer = initializer
+	assert.Equal(t, ServiceID("test-service"), untypedInitializer.GetService("test-service", "1.2.3.4").GetServiceID())
+}
+
+func TestBuilderReportsAllProblems(t *testing.T) {
+	_, err := NewDockerContainerInitializerBuilder[*MockService]().
+		WithGeneratedFile(testBuilderConfigFileKey, func(fp *os.File) error { return nil }).
+		WithGeneratedFile(testBuilderConfigFileKey, nil).
+		WithFile(testBuilderConfigFileKey, nil).
+		WithFilesArtifactMount("artifact", "relative/dir").
+		WithSharedVolumeMount("keystore", "relative/keystore").
+		WithUsedPorts(Port{Number: 0, Protocol: TCP}).
+		WithStartCommand("{{.IPAddress").
+		Build()
+	assert.Error(t, err)
+
+	errStr := err.Error()
+	assert.Contains(t, errStr, "No Docker image was set")
+	assert.Contains(t, errStr, "No test volume mountpoint was set")
+	assert.Contains(t, errStr, "No service factory was set")
+	assert.Contains(t, errStr, "Generated file key 'config' was declared more than once")
+	assert.Contains(t, errStr, "Generated file 'config' has a nil content generator")
+	assert.Contains(t, errStr, "Generated file 'config' is nil")
+	assert.Contains(t, errStr, "Mountpoint 'relative/dir' of files artifact 'artifact' isn't absolute")
+	assert.Contains(t, errStr, "Mountpoint 'relative/keystore' of shared volume 'keystore' isn't absolute")
+	assert.Contains(t, errStr, "Start command argument 0 couldn't be parsed as a template")
+	assert.Contains(t, errStr, "Port '0/tcp' has number 0")
+}
+
+func TestBuilderRejectsRelativeTestVolumeMountpoint(t *testing.T) {
+	_, err := NewDockerContainerInitializerBuilder[*MockService]().
+		WithDockerImage(testBuilderImage).
+		WithTestVolumeMountpoint("test-volume").
+		WithServiceFactory(newMockServiceForBuilder).
+		Build()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Test volume mountpoint 'test-volume' isn't absolute")
+}
+
+func TestBuilderStartCommandMissingGeneratedFile(t *testing.T) {
+	initializer, err := NewDockerContainerInitializerBuilder[*MockService]().
+		WithDockerImage(testBuilderImage).
+		WithTestVolumeMountpoint(testBuilderVolumeMountpoint).
+		WithStartCommand("--config={{.GeneratedFilepaths.config}}").
+		WithServiceFactory(newMockServiceForBuilder).
+		Build()
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred building the initializer"))
+	}
+
+	_, err = initializer.GetStartCommand(map[string]string{}, "1.2.3.4")
+	assert.Error(t, err)
+}
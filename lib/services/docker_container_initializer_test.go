@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+// Implements only the required initializer methods, like an initializer written before the optional ones existed
+type minimalTestInitializer struct{}
+
+func (initializer minimalTestInitializer) GetDockerImage() string {
+	return "test-image"
+}
+
+func (initializer minimalTestInitializer) GetUsedPorts() []Port {
+	return []Port{}
+}
+
+func (initializer minimalTestInitializer) GetService(serviceId ServiceID, ipAddr string) Service {
+	return NewMockService(serviceId, ipAddr, 1)
+}
+
+func (initializer minimalTestInitializer) GetFilesToMount() map[string]bool {
+	return map[string]bool{}
+}
+
+func (initializer minimalTestInitializer) InitializeMountedFiles(mountedFiles map[string]*os.File) error {
+	return nil
+}
+
+func (initializer minimalTestInitializer) GetFilesArtifactMountpoints() map[FilesArtifactID]string {
+	return map[FilesArtifactID]string{}
+}
+
+func (initializer minimalTestInitializer) GetTestVolumeMountpoint() string {
+	return "/test-volume"
+}
+
+func (initializer minimalTestInitializer) GetStartCommand(mountedFileFilepaths map[string]string, ipAddr string) ([]string, error) {
+	return nil, nil
+}
+
+func TestOptionalInitializerMethodDefaults(t *testing.T) {
+	var initializer DockerContainerInitializer = minimalTestInitializer{}
+	assert.Equal(t, ResourceLimits{}, GetInitializerResourceLimits(initializer))
+	assert.IsType(t, &IsAvailableCheck{}, GetInitializerAvailabilityCheck(initializer))
+	assert.Empty(t, GetInitializerDependencies(initializer))
+	assert.Empty(t, GetInitializerFileTemplates(initializer))
+	assert.Empty(t, GetInitializerSharedVolumeMountpoints(initializer))
+	assert.Empty(t, GetInitializerGeneratedFiles(initializer))
+}
+
+func TestOptionalInitializerMethods(t *testing.T) {
+	initializer := NewMockDockerContainerInitializer()
+	initializer.ResourceLimits = ResourceLimits{CpuMillicores: 500}
+	assert.Equal(t, initializer.ResourceLimits, GetInitializerResourceLimits(initializer))
+
+	// An initializer that doesn't select a check also gets the default
+	assert.IsType(t, &IsAvailableCheck{}, GetInitializerAvailabilityCheck(initializer))
+	initializer.AvailabilityCheck = NewTcpCheck(1000)
+	assert.Equal(t, initializer.AvailabilityCheck, GetInitializerAvailabilityCheck(initializer))
+
+	initializer.Dependencies = map[ServiceID]bool{"dependency": true}
+	assert.Equal(t, initializer.Dependencies, GetInitializerDependencies(initializer))
+
+	initializer.FileTemplates = map[string]*FileTemplate{"config": {}}
+	assert.Equal(t, initializer.FileTemplates, GetInitializerFileTemplates(initializer))
+
+	initializer.SharedVolumeMountpoints = map[SharedVolumeID]string{"keystore": "/keystore"}
+	assert.Equal(t, initializer.SharedVolumeMountpoints, GetInitializerSharedVolumeMountpoints(initializer))
+
+	initializer.GeneratedFiles = map[string]*GeneratedFile{"data": NewGeneratedDirectory(map[string]*GeneratedFile{}, 0755)}
+	assert.Equal(t, initializer.GeneratedFiles, GetInitializerGeneratedFiles(initializer))
+}
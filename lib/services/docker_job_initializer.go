@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"os"
+)
+
+/*
+The counterpart of DockerContainerInitializer for one-shot jobs (e.g. schema migrations, data loaders, load generators),
+	which are containers that run inside the test network until they exit rather than long-running services.
+The methods shared with DockerContainerInitializer have the exact same semantics as they do there, and a job can
+	mount shared volumes by also implementing SharedVolumesInitializer.
+ */
+type DockerJobInitializer interface {
+	// Gets the Docker image that will be used for instantiating the Docker container
+	GetDockerImage() string
+
+	// See DockerContainerInitializer.GetFilesToMount
+	GetFilesToMount() map[string]bool
+
+	// See DockerContainerInitializer.InitializeMountedFiles
+	InitializeMountedFiles(mountedFiles map[string]*os.File) error
+
+	// See DockerContainerInitializer.GetFilesArtifactMountpoints
+	GetFilesArtifactMountpoints() map[FilesArtifactID]string
+
+	// See DockerContainerInitializer.GetTestVolumeMountpoint
+	GetTestVolumeMountpoint() string
+
+	// See DockerContainerInitializer.GetStartCommand
+	GetStartCommand(mountedFileFilepaths map[string]string, ipAddr string) ([]string, error)
+
+	/*
+		Declares the files and directories that the job produces which should be collected after the job exits.
+		Returns:
+			A map of developer_key -> absolute_path_in_job_container, where the developer_key is how the output will be
+				identified in the JobResult
+	 */
+	GetOutputFilepaths() map[string]string
+}
@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"github.com/palantir/stacktrace"
+	"io"
+	"os"
+	"sort"
+	"text/template"
+)
+
+const (
+	serviceIpTemplateFuncName = "serviceIP"
+	servicePortsTemplateFuncName = "servicePorts"
+	servicePortTemplateFuncName = "servicePort"
+)
+
+/*
+The data that a FileTemplate is rendered with, e.g.:
+
+	listen_address = "{{.IPAddress}}"
+	peer = "{{serviceIP "node-1"}}"
+	log_level = "{{.Values.logLevel}}"
+
+Besides the fields below, templates can call:
+	serviceIP "<service ID>"		The IP address of another service in the network
+	servicePorts "<service ID>"		The sorted ports, in Docker port specification syntax, of another service in the network
+	servicePort "<service ID>" "<port name>"	The named Port of another service in the network, e.g.
+										{{(servicePort "datastore" "http").Number}}
+These fail the rendering if no service with the given ID is in the network, or it has no port with the given name.
+The rendered file can also contain the "${service:<service ID>:ip}" & "${service:<service ID>:port:<port name>}"
+	references accepted in start commands, which are resolved after rendering.
+ */
+type FileTemplateData struct {
+	// The ID of the service whose file is being rendered
+	ServiceID ServiceID
+
+	// The IP address of the service's container, which is the same IP address passed to GetStartCommand
+	IPAddress string
+
+	// Mapping of generated file key -> the filepath of the generated file *on the service's container*, as passed to
+	//  GetStartCommand
+	GeneratedFilepaths map[string]string
+
+	// The other services that are in the network at the time the file is rendered
+	Services map[ServiceID]FileTemplateServiceData
+
+	// The user-provided values that the template was created with
+	Values map[string]interface{}
+}
+
+// Information about another service in the network, available to file templates
+type FileTemplateServiceData struct {
+	IPAddress string
+
+	UsedPorts []Port
+}
+
+/*
+A generated file whose contents are a Go text/template, which Kurtosis renders with FileTemplateData when the service
+	is started rather than the developer hand-writing the file in InitializeMountedFiles
+ */
+type FileTemplate struct {
+	template *template.Template
+	values map[string]interface{}
+
+	// 0 means the default mode should be used
+	mode os.FileMode
+}
+
+/*
+Creates a file template
+
+Args:
+	templateStr: The Go text/template of the file's contents, which will error on render if it references a value
+		that doesn't exist
+	values: User-provided values available to the template as {{.Values.<key>}}, which may be nil
+	mode: The permissions of the rendered file (e.g. 0600 for a key file, or 0755 for a script), or 0 for 0644
+ */
+func NewFileTemplate(templateStr string, values map[string]interface{}, mode os.FileMode) (*FileTemplate, error) {
+	if mode &^ settableModeBits != 0 {
+		return nil, stacktrace.NewError("Mode '%v' has bits other than permission bits set", mode)
+	}
+	// The functions are only placeholders so the template parses; they're rebound to the network's services on render
+	placeholderFuncs := newFileTemplateFuncs(map[ServiceID]FileTemplateServiceData{})
+	parsedTemplate, err := template.New("").Option("missingkey=error").Funcs(placeholderFuncs).Parse(templateStr)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the file template")
+	}
+	valuesCopy := map[string]interface{}{}
+	for key, value := range values {
+		valuesCopy[key] = value
+	}
+	return &FileTemplate{
+		template: parsedTemplate,
+		values:   valuesCopy,
+		mode:     mode,
+	}, nil
+}
+
+// Gets the permissions that the rendered file should have
+func (fileTemplate FileTemplate) GetMode() os.FileMode {
+	if fileTemplate.mode == 0 {
+		return defaultGeneratedFileMode
+	}
+	return fileTemplate.mode
+}
+
+/*
+Renders the template to the given writer
+
+Args:
+	writer: The writer the rendered contents will be written to
+	data: The data to render the template with, whose Values field will be replaced by the template's values
+ */
+func (fileTemplate FileTemplate) Render(writer io.Writer, data FileTemplateData) error {
+	boundTemplate, err := fileTemplate.template.Clone()
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred cloning the file template")
+	}
+	boundTemplate.Funcs(newFileTemplateFuncs(data.Services))
+
+	data.Values = fileTemplate.values
+	if err := boundTemplate.Execute(writer, data); err != nil {
+		return stacktrace.Propagate(err, "An error occurred rendering the file template")
+	}
+	return nil
+}
+
+func newFileTemplateFuncs(serviceData map[ServiceID]FileTemplateServiceData) template.FuncMap {
+	return template.FuncMap{
+		serviceIpTemplateFuncName: func(serviceId ServiceID) (string, error) {
+			data, found := serviceData[serviceId]
+			if !found {
+				return "", stacktrace.NewError("No service with ID '%v' is in the network", serviceId)
+			}
+			return data.IPAddress, nil
+		},
+		servicePortsTemplateFuncName: func(serviceId ServiceID) ([]string, error) {
+			data, found := serviceData[serviceId]
+			if !found {
+				return nil, stacktrace.NewError("No service with ID '%v' is in the network", serviceId)
+			}
+			result := []string{}
+			for _, port := range data.UsedPorts {
+				result = append(result, port.String())
+			}
+			sort.Strings(result)
+			return result, nil
+		},
+		servicePortTemplateFuncName: func(serviceId ServiceID, portName string) (Port, error) {
+			data, found := serviceData[serviceId]
+			if !found {
+				return Port{}, stacktrace.NewError("No service with ID '%v' is in the network", serviceId)
+			}
+			port, err := GetPortByName(data.UsedPorts, portName)
+			if err != nil {
+				return Port{}, stacktrace.Propagate(err, "An error occurred getting port '%v' of service '%v'", portName, serviceId)
+			}
+			return port, nil
+		},
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"bytes"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"testing"
+)
+
+func newTestFileTemplateData() FileTemplateData {
+	return FileTemplateData{
+		ServiceID:          "api",
+		IPAddress:          "1.2.3.4",
+		GeneratedFilepaths: map[string]string{"config": "/test-volume/config"},
+		Services: map[ServiceID]FileTemplateServiceData{
+			"datastore": {
+				IPAddress: "5.6.7.8",
+				UsedPorts: []Port{{Number: 9000, Protocol: UDP}, {Number: 1323, Protocol: TCP, Name: "http"}},
+			},
+		},
+	}
+}
+
+func TestFileTemplateRender(t *testing.T) {
+	fileTemplate, err := NewFileTemplate(
+		`{{.ServiceID}} {{.IPAddress}} {{index .GeneratedFilepaths "config"}} {{serviceIP "datastore"}} {{servicePorts "datastore"}} {{(servicePort "datastore" "http").Number}} {{.Values.port}}`,
+		map[string]interface{}{"port": 8080},
+		0)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the file template"))
+	}
+
+	buffer := &bytes.Buffer{}
+	if err := fileTemplate.Render(buffer, newTestFileTemplateData()); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred rendering the file template"))
+	}
+	assert.Equal(t, "api 1.2.3.4 /test-volume/config 5.6.7.8 [1323/tcp 9000/udp] 1323 8080", buffer.String())
+}
+
+func TestFileTemplateUnknownService(t *testing.T) {
+	fileTemplate, err := NewFileTemplate(`{{serviceIP "nonexistent"}}`, nil, 0)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the file template"))
+	}
+	err = fileTemplate.Render(&bytes.Buffer{}, newTestFileTemplateData())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "nonexistent")
+}
+
+func TestFileTemplateUnknownPortName(t *testing.T) {
+	fileTemplate, err := NewFileTemplate(`{{servicePort "datastore" "grpc"}}`, nil, 0)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the file template"))
+	}
+	assert.Error(t, fileTemplate.Render(&bytes.Buffer{}, newTestFileTemplateData()))
+}
+
+func TestFileTemplateMissingValue(t *testing.T) {
+	fileTemplate, err := NewFileTemplate(`{{.Values.missing}}`, map[string]interface{}{}, 0)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the file template"))
+	}
+	assert.Error(t, fileTemplate.Render(&bytes.Buffer{}, newTestFileTemplateData()))
+}
+
+func TestFileTemplateMode(t *testing.T) {
+	defaultModeTemplate, err := NewFileTemplate("contents", nil, 0)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the file template with the default mode"))
+	}
+	assert.Equal(t, os.FileMode(0644), defaultModeTemplate.GetMode())
+
+	scriptTemplate, err := NewFileTemplate("contents", nil, 0755)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the file template with an explicit mode"))
+	}
+	assert.Equal(t, os.FileMode(0755), scriptTemplate.GetMode())
+
+	_, err = NewFileTemplate("contents", nil, os.ModeDir | 0755)
+	assert.Error(t, err)
+}
+
+func TestFileTemplateInvalidSyntax(t *testing.T) {
+	_, err := NewFileTemplate(`{{.IPAddress`, nil, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,267 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultGeneratedFileMode os.FileMode = 0644
+	defaultGeneratedDirMode os.FileMode = 0755
+
+	// The mode bits that can be set explicitly on a generated file or directory
+	settableModeBits = os.ModePerm | os.ModeSetuid | os.ModeSetgid | os.ModeSticky
+)
+
+type generatedFileKind int
+
+const (
+	bytesGeneratedFileKind generatedFileKind = iota
+	testsuitePathGeneratedFileKind
+	directoryGeneratedFileKind
+)
+
+/*
+A file or directory that Kurtosis generates for a service (see GeneratedFilesInitializer.GetGeneratedFiles),
+	created with one of:
+
+	NewGeneratedFileFromBytes			A regular file with the given contents
+	NewGeneratedFileFromTestsuitePath	A copy of a file or directory that's already in the testsuite's image
+	NewGeneratedDirectory				A directory containing other generated files & directories
+ */
+type GeneratedFile struct {
+	kind generatedFileKind
+
+	// Only set for bytesGeneratedFileKind
+	contents []byte
+
+	// Only set for testsuitePathGeneratedFileKind
+	testsuitePath string
+
+	// Only set for directoryGeneratedFileKind, keyed by the name of the entry within the directory
+	entries map[string]*GeneratedFile
+
+	// 0 means the default mode should be used, or for testsuite paths that the source's mode should be kept
+	mode os.FileMode
+}
+
+/*
+Creates a regular file with the given contents, which can be binary
+
+Args:
+	contents: The contents of the file
+	mode: The permissions of the file (e.g. 0600 for a key file, or 0755 for a script), or 0 for 0644
+ */
+func NewGeneratedFileFromBytes(contents []byte, mode os.FileMode) *GeneratedFile {
+	return &GeneratedFile{
+		kind:     bytesGeneratedFileKind,
+		contents: append([]byte{}, contents...),
+		mode:     mode,
+	}
+}
+
+/*
+Copies a file or directory that's already in the testsuite's image, e.g. a directory of static config. Directories are
+	copied recursively, keeping the modes of their contents.
+
+Args:
+	testsuitePath: The absolute path of the file or directory on the testsuite container
+	mode: The permissions of the copied file (or top-level directory), or 0 to keep the source's permissions
+ */
+func NewGeneratedFileFromTestsuitePath(testsuitePath string, mode os.FileMode) *GeneratedFile {
+	return &GeneratedFile{
+		kind:          testsuitePathGeneratedFileKind,
+		testsuitePath: testsuitePath,
+		mode:          mode,
+	}
+}
+
+/*
+Creates a directory containing the given files & directories
+
+Args:
+	entries: Mapping of the name of the entry within the directory -> the entry, where names can't contain '/'
+	mode: The permissions of the directory, or 0 for 0755
+ */
+func NewGeneratedDirectory(entries map[string]*GeneratedFile, mode os.FileMode) *GeneratedFile {
+	entriesCopy := map[string]*GeneratedFile{}
+	for name, entry := range entries {
+		entriesCopy[name] = entry
+	}
+	return &GeneratedFile{
+		kind:    directoryGeneratedFileKind,
+		entries: entriesCopy,
+		mode:    mode,
+	}
+}
+
+/*
+Checks that the generated file can be created, e.g. that its testsuite path exists and its directory entries have
+	valid names
+
+Returns:
+	An error listing every problem with the generated file (including its directory entries), or nil if there are none
+ */
+func (generatedFile *GeneratedFile) Validate() error {
+	problems := generatedFile.getProblems("")
+	if len(problems) > 0 {
+		return stacktrace.NewError("The generated file is invalid:\n%v", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+/*
+Creates the generated file at the given path on the testsuite container, replacing anything already there
+ */
+func (generatedFile *GeneratedFile) Generate(destPath string) error {
+	if err := os.RemoveAll(destPath); err != nil {
+		return stacktrace.Propagate(err, "An error occurred removing the existing contents of '%v'", destPath)
+	}
+
+	switch generatedFile.kind {
+	case bytesGeneratedFileKind:
+		if err := os.WriteFile(destPath, generatedFile.contents, defaultGeneratedFileMode); err != nil {
+			return stacktrace.Propagate(err, "An error occurred writing file '%v'", destPath)
+		}
+		return chmodIgnoringUmask(destPath, generatedFile.mode, defaultGeneratedFileMode)
+	case testsuitePathGeneratedFileKind:
+		if err := copyTestsuitePath(generatedFile.testsuitePath, destPath); err != nil {
+			return stacktrace.Propagate(err, "An error occurred copying testsuite path '%v' to '%v'", generatedFile.testsuitePath, destPath)
+		}
+		if generatedFile.mode == 0 {
+			return nil
+		}
+		return chmodIgnoringUmask(destPath, generatedFile.mode, generatedFile.mode)
+	case directoryGeneratedFileKind:
+		// The directory's mode is only applied once it's populated, in case the mode doesn't permit writing to it
+		if err := os.Mkdir(destPath, defaultGeneratedDirMode); err != nil {
+			return stacktrace.Propagate(err, "An error occurred creating directory '%v'", destPath)
+		}
+		for name, entry := range generatedFile.entries {
+			if err := entry.Generate(path.Join(destPath, name)); err != nil {
+				return stacktrace.Propagate(err, "An error occurred generating entry '%v' of directory '%v'", name, destPath)
+			}
+		}
+		return chmodIgnoringUmask(destPath, generatedFile.mode, defaultGeneratedDirMode)
+	default:
+		return stacktrace.NewError("Unrecognized generated file kind '%v'; this is a bug in Kurtosis", generatedFile.kind)
+	}
+}
+
+// ====================================================================================================
+//                                       Private helper methods
+// ====================================================================================================
+// Gets the problems with the generated file, which is at the given path within the top-level directory (if any)
+func (generatedFile *GeneratedFile) getProblems(location string) []string {
+	problems := []string{}
+	addProblem := func(problem string) {
+		if location != "" {
+			problem = fmt.Sprintf("Entry '%v': %v", location, problem)
+		}
+		problems = append(problems, problem)
+	}
+
+	if generatedFile.mode &^ settableModeBits != 0 {
+		addProblem(fmt.Sprintf("Mode '%v' has bits other than permission bits set", generatedFile.mode))
+	}
+
+	switch generatedFile.kind {
+	case testsuitePathGeneratedFileKind:
+		if !path.IsAbs(generatedFile.testsuitePath) {
+			addProblem(fmt.Sprintf("Testsuite path '%v' isn't an absolute path", generatedFile.testsuitePath))
+		} else if _, err := os.Stat(generatedFile.testsuitePath); err != nil {
+			addProblem(fmt.Sprintf("Testsuite path '%v' can't be read: %v", generatedFile.testsuitePath, err))
+		}
+	case directoryGeneratedFileKind:
+		sortedNames := []string{}
+		for name := range generatedFile.entries {
+			sortedNames = append(sortedNames, name)
+		}
+		sort.Strings(sortedNames)
+		for _, name := range sortedNames {
+			entry := generatedFile.entries[name]
+			if name == "" || name == "." || name == ".." || strings.Contains(name, "/") {
+				addProblem(fmt.Sprintf("Directory entry name '%v' isn't a valid filename", name))
+				continue
+			}
+			if entry == nil {
+				addProblem(fmt.Sprintf("Directory entry '%v' is nil", name))
+				continue
+			}
+			problems = append(problems, entry.getProblems(path.Join(location, name))...)
+		}
+	}
+	return problems
+}
+
+// Sets the mode of the given path, which os.WriteFile & os.Mkdir don't do exactly because they're subject to the umask
+func chmodIgnoringUmask(filepath string, mode os.FileMode, defaultMode os.FileMode) error {
+	if mode == 0 {
+		mode = defaultMode
+	}
+	if err := os.Chmod(filepath, mode); err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting the mode of '%v' to '%v'", filepath, mode)
+	}
+	return nil
+}
+
+// Recursively copies the given file, directory, or symlink, keeping the modes of everything copied
+func copyTestsuitePath(srcPath string, destPath string) error {
+	srcInfo, err := os.Lstat(srcPath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting info about '%v'", srcPath)
+	}
+
+	switch {
+	case srcInfo.Mode() & os.ModeSymlink != 0:
+		linkTarget, err := os.Readlink(srcPath)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred reading symlink '%v'", srcPath)
+		}
+		if err := os.Symlink(linkTarget, destPath); err != nil {
+			return stacktrace.Propagate(err, "An error occurred creating symlink '%v'", destPath)
+		}
+		return nil
+	case srcInfo.IsDir():
+		if err := os.Mkdir(destPath, defaultGeneratedDirMode); err != nil {
+			return stacktrace.Propagate(err, "An error occurred creating directory '%v'", destPath)
+		}
+		dirEntries, err := os.ReadDir(srcPath)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred reading directory '%v'", srcPath)
+		}
+		for _, dirEntry := range dirEntries {
+			if err := copyTestsuitePath(path.Join(srcPath, dirEntry.Name()), path.Join(destPath, dirEntry.Name())); err != nil {
+				return stacktrace.Propagate(err, "An error occurred copying entry '%v' of directory '%v'", dirEntry.Name(), srcPath)
+			}
+		}
+		return chmodIgnoringUmask(destPath, srcInfo.Mode() & settableModeBits, defaultGeneratedDirMode)
+	case srcInfo.Mode().IsRegular():
+		srcFp, err := os.Open(srcPath)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred opening file '%v'", srcPath)
+		}
+		defer srcFp.Close()
+		destFp, err := os.OpenFile(destPath, os.O_WRONLY | os.O_CREATE | os.O_EXCL, defaultGeneratedFileMode)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred creating file '%v'", destPath)
+		}
+		defer destFp.Close()
+		if _, err := io.Copy(destFp, srcFp); err != nil {
+			return stacktrace.Propagate(err, "An error occurred copying the contents of file '%v'", srcPath)
+		}
+		return chmodIgnoringUmask(destPath, srcInfo.Mode() & settableModeBits, defaultGeneratedFileMode)
+	default:
+		return stacktrace.NewError("'%v' isn't a regular file, directory, or symlink", srcPath)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestGenerateFileFromBytes(t *testing.T) {
+	destFilepath := path.Join(t.TempDir(), "key")
+	// The file already existing shouldn't matter, as the Kurtosis API may have created it
+	if err := os.WriteFile(destFilepath, []byte("placeholder"), 0644); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the placeholder file"))
+	}
+
+	contents := []byte{0x00, 0xff, 0x10}
+	if err := NewGeneratedFileFromBytes(contents, 0600).Generate(destFilepath); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred generating the file"))
+	}
+	assertFileContentsAndMode(t, destFilepath, contents, 0600)
+}
+
+func TestGenerateDirectory(t *testing.T) {
+	destDirpath := path.Join(t.TempDir(), "config")
+	generatedDir := NewGeneratedDirectory(map[string]*GeneratedFile{
+		"run.sh": NewGeneratedFileFromBytes([]byte("#!/bin/sh"), 0755),
+		"keys": NewGeneratedDirectory(map[string]*GeneratedFile{
+			"node.key": NewGeneratedFileFromBytes([]byte("secret"), 0400),
+		}, 0700),
+		"empty.conf": NewGeneratedFileFromBytes(nil, 0),
+	}, 0)
+	if err := generatedDir.Validate(); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "The generated directory was unexpectedly invalid"))
+	}
+	if err := generatedDir.Generate(destDirpath); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred generating the directory"))
+	}
+
+	assertDirMode(t, destDirpath, defaultGeneratedDirMode)
+	assertFileContentsAndMode(t, path.Join(destDirpath, "run.sh"), []byte("#!/bin/sh"), 0755)
+	assertDirMode(t, path.Join(destDirpath, "keys"), 0700)
+	assertFileContentsAndMode(t, path.Join(destDirpath, "keys", "node.key"), []byte("secret"), 0400)
+	assertFileContentsAndMode(t, path.Join(destDirpath, "empty.conf"), []byte{}, defaultGeneratedFileMode)
+}
+
+func TestGenerateFileFromTestsuitePath(t *testing.T) {
+	srcDirpath := path.Join(t.TempDir(), "static")
+	if err := os.MkdirAll(path.Join(srcDirpath, "nested"), 0755); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the source directory"))
+	}
+	srcScriptFilepath := path.Join(srcDirpath, "nested", "script.sh")
+	if err := os.WriteFile(srcScriptFilepath, []byte("echo hi"), 0644); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the source script"))
+	}
+	if err := os.Chmod(srcScriptFilepath, 0750); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred setting the mode of the source script"))
+	}
+	if err := os.Symlink("nested/script.sh", path.Join(srcDirpath, "link.sh")); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the source symlink"))
+	}
+
+	destParentDirpath := t.TempDir()
+
+	// Directories are copied recursively, keeping the modes of their contents
+	destDirpath := path.Join(destParentDirpath, "static")
+	if err := NewGeneratedFileFromTestsuitePath(srcDirpath, 0).Generate(destDirpath); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred generating the directory copy"))
+	}
+	assertFileContentsAndMode(t, path.Join(destDirpath, "nested", "script.sh"), []byte("echo hi"), 0750)
+	linkTarget, err := os.Readlink(path.Join(destDirpath, "link.sh"))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading the copied symlink"))
+	}
+	assert.Equal(t, "nested/script.sh", linkTarget)
+
+	// An explicit mode overrides the source's
+	destFilepath := path.Join(destParentDirpath, "script.sh")
+	if err := NewGeneratedFileFromTestsuitePath(srcScriptFilepath, 0700).Generate(destFilepath); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred generating the file copy"))
+	}
+	assertFileContentsAndMode(t, destFilepath, []byte("echo hi"), 0700)
+}
+
+func TestGeneratedFileValidationReportsEveryProblem(t *testing.T) {
+	generatedDir := NewGeneratedDirectory(map[string]*GeneratedFile{
+		"../escape": NewGeneratedFileFromBytes(nil, 0),
+		"nil-entry": nil,
+		"subdir": NewGeneratedDirectory(map[string]*GeneratedFile{
+			"relative":    NewGeneratedFileFromTestsuitePath("relative/path", 0),
+			"nonexistent": NewGeneratedFileFromTestsuitePath("/nonexistent/path", 0),
+		}, 0),
+		"bad-mode": NewGeneratedFileFromBytes(nil, os.ModeDir | 0755),
+	}, 0)
+	err := generatedDir.Validate()
+	assert.Error(t, err)
+	errStr := err.Error()
+	assert.Contains(t, errStr, "Directory entry name '../escape' isn't a valid filename")
+	assert.Contains(t, errStr, "Directory entry 'nil-entry' is nil")
+	assert.Contains(t, errStr, "Entry 'subdir/relative': Testsuite path 'relative/path' isn't an absolute path")
+	assert.Contains(t, errStr, "Entry 'subdir/nonexistent': Testsuite path '/nonexistent/path' can't be read")
+	assert.Contains(t, errStr, "Entry 'bad-mode': Mode 'drwxr-xr-x' has bits other than permission bits set")
+}
+
+func assertFileContentsAndMode(t *testing.T, filepath string, expectedContents []byte, expectedMode os.FileMode) {
+	contents, err := os.ReadFile(filepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred reading file '%v'", filepath))
+	}
+	assert.Equal(t, expectedContents, contents)
+	info, err := os.Stat(filepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting info about file '%v'", filepath))
+	}
+	assert.Equal(t, expectedMode, info.Mode().Perm())
+}
+
+func assertDirMode(t *testing.T, dirpath string, expectedMode os.FileMode) {
+	info, err := os.Stat(dirpath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting info about directory '%v'", dirpath))
+	}
+	assert.True(t, info.IsDir())
+	assert.Equal(t, expectedMode, info.Mode().Perm())
+}
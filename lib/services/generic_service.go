@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"github.com/palantir/stacktrace"
+)
+
+/*
+A Service for containers defined by a ServiceSpec, which have no developer-written interface. Whether the service is
+	available is determined entirely by the spec's health check, so IsAvailable always returns true.
+ */
+type GenericService struct {
+	serviceId ServiceID
+	ipAddr string
+	usedPorts []Port
+}
+
+func NewGenericService(serviceId ServiceID, ipAddr string, usedPorts []Port) *GenericService {
+	return &GenericService{
+		serviceId: serviceId,
+		ipAddr:    ipAddr,
+		usedPorts: append([]Port{}, usedPorts...),
+	}
+}
+
+func (service GenericService) GetServiceID() ServiceID {
+	return service.serviceId
+}
+
+func (service GenericService) GetIPAddress() string {
+	return service.ipAddr
+}
+
+// Gets the ports that the service listens on
+func (service GenericService) GetUsedPorts() []Port {
+	return append([]Port{}, service.usedPorts...)
+}
+
+// Gets the port with the given name, as declared in the service's spec
+func (service GenericService) GetPort(portName string) (Port, error) {
+	port, err := GetPortByName(service.usedPorts, portName)
+	if err != nil {
+		return Port{}, stacktrace.Propagate(err, "An error occurred getting port '%v' of service '%v'", portName, service.serviceId)
+	}
+	return port, nil
+}
+
+func (service GenericService) IsAvailable() bool {
+	return true
+}
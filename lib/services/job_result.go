@@ -0,0 +1,21 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+/*
+The result of running a job container to completion
+ */
+type JobResult struct {
+	// The exit code that the job's container exited with
+	ExitCode int32
+
+	// The combined STDOUT & STDERR output of the job's container
+	Logs []byte
+
+	// Mapping of developer_key -> absolute filepath *on the testsuite container* where the output declared
+	//  in DockerJobInitializer.GetOutputFilepaths was copied to
+	OutputFilepaths map[string]string
+}
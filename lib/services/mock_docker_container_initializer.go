@@ -6,24 +6,61 @@
 package services
 
 import (
-	"fmt"
 	"os"
 )
 
-type MockDockerContainerInitializer struct{}
+/*
+A DockerContainerInitializer for tests, whose methods return the corresponding fields so that each test can configure
+	only what it cares about. NewMockDockerContainerInitializer fills the fields with a minimal working container.
+ */
+type MockDockerContainerInitializer struct {
+	DockerImage  string
+	UsedPorts    []Port
+	FilesToMount map[string]bool
+
+	// If non-nil, called by InitializeMountedFiles
+	InitializeMountedFilesFunc func(mountedFiles map[string]*os.File) error
+
+	FileTemplates            map[string]*FileTemplate
+	GeneratedFiles           map[string]*GeneratedFile
+	FilesArtifactMountpoints map[FilesArtifactID]string
+	SharedVolumeMountpoints  map[SharedVolumeID]string
+	ResourceLimits           ResourceLimits
+	AvailabilityCheck        AvailabilityCheck
+	Dependencies             map[ServiceID]bool
+	TestVolumeMountpoint     string
+	StartCommand             []string
+}
 
 func NewMockDockerContainerInitializer() *MockDockerContainerInitializer {
-	return &MockDockerContainerInitializer{}
+	return &MockDockerContainerInitializer{
+		DockerImage: "some-image",
+		UsedPorts: []Port{
+			{Number: MockServicePort, Protocol: TCP, Name: MockServicePortName},
+		},
+		FilesToMount:               map[string]bool{},
+		InitializeMountedFilesFunc: nil,
+		FileTemplates:              map[string]*FileTemplate{},
+		GeneratedFiles:             map[string]*GeneratedFile{},
+		FilesArtifactMountpoints:   map[FilesArtifactID]string{},
+		SharedVolumeMountpoints:    map[SharedVolumeID]string{},
+		ResourceLimits:             ResourceLimits{},
+		AvailabilityCheck:          nil,
+		Dependencies:               map[ServiceID]bool{},
+		TestVolumeMountpoint:       "/test-volume",
+		StartCommand: []string{
+			"some-binary",
+			"--some-flag",
+		},
+	}
 }
 
 func (m MockDockerContainerInitializer) GetDockerImage() string {
-	return "some-image"
+	return m.DockerImage
 }
 
-func (m MockDockerContainerInitializer) GetUsedPorts() map[string]bool {
-	return map[string]bool{
-		fmt.Sprintf("%v/tcp", MockServicePort): true,
-	}
+func (m MockDockerContainerInitializer) GetUsedPorts() []Port {
+	return m.UsedPorts
 }
 
 func (m MockDockerContainerInitializer) GetService(serviceId ServiceID, ipAddr string) Service {
@@ -31,25 +68,61 @@ func (m MockDockerContainerInitializer) GetService(serviceId ServiceID, ipAddr s
 }
 
 func (m MockDockerContainerInitializer) GetFilesToMount() map[string]bool {
-	return map[string]bool{}
+	return m.FilesToMount
 }
 
 func (m MockDockerContainerInitializer) InitializeMountedFiles(mountedFiles map[string]*os.File) error {
-	return nil
+	if m.InitializeMountedFilesFunc == nil {
+		return nil
+	}
+	return m.InitializeMountedFilesFunc(mountedFiles)
+}
+
+func (m MockDockerContainerInitializer) GetFileTemplates() map[string]*FileTemplate {
+	return m.FileTemplates
+}
+
+func (m MockDockerContainerInitializer) GetGeneratedFiles() map[string]*GeneratedFile {
+	return m.GeneratedFiles
 }
 
 func (m MockDockerContainerInitializer) GetFilesArtifactMountpoints() map[FilesArtifactID]string {
-	return map[FilesArtifactID]string{}
+	return m.FilesArtifactMountpoints
+}
+
+func (m MockDockerContainerInitializer) GetSharedVolumeMountpoints() map[SharedVolumeID]string {
+	return m.SharedVolumeMountpoints
+}
+
+func (m MockDockerContainerInitializer) GetResourceLimits() ResourceLimits {
+	return m.ResourceLimits
+}
+
+func (m MockDockerContainerInitializer) GetAvailabilityCheck() AvailabilityCheck {
+	return m.AvailabilityCheck
+}
+
+func (m MockDockerContainerInitializer) GetDependencies() map[ServiceID]bool {
+	return m.Dependencies
 }
 
 func (m MockDockerContainerInitializer) GetTestVolumeMountpoint() string {
-	return "/test-volume"
+	return m.TestVolumeMountpoint
 }
 
 func (m MockDockerContainerInitializer) GetStartCommand(mountedFileFilepaths map[string]string, ipPlaceholder string) ([]string, error) {
-	return []string{
-		"some-binary",
-		"--some-flag",
-	}, nil
+	return m.StartCommand, nil
 }
 
+// A MockDockerContainerInitializer for testing typed initializers, whose services are *MockService
+type MockTypedDockerContainerInitializer struct {
+	MockDockerContainerInitializer
+}
+
+func NewMockTypedDockerContainerInitializer() *MockTypedDockerContainerInitializer {
+	return &MockTypedDockerContainerInitializer{MockDockerContainerInitializer: *NewMockDockerContainerInitializer()}
+}
+
+func (m MockTypedDockerContainerInitializer) GetService(serviceId ServiceID, ipAddr string) *MockService {
+	return NewMockService(serviceId, ipAddr, 1)
+}
@@ -7,6 +7,7 @@ package services
 
 const (
 	MockServicePort = 1000
+	MockServicePortName = "mock"
 )
 
 // Mock service, for testing purposes only
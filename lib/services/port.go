@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"strings"
+)
+
+type PortProtocol string
+
+const (
+	TCP PortProtocol = "tcp"
+	UDP PortProtocol = "udp"
+	SCTP PortProtocol = "sctp"
+)
+
+/*
+A port that a service's container listens on, e.g.:
+
+	services.Port{Number: 8080, Protocol: services.TCP, Name: "http"}
+
+The name is optional, but naming a port lets clients look it up (e.g. via NetworkContext.GetServicePort) rather than
+	duplicating port constants in every Service implementation.
+ */
+type Port struct {
+	// Required; between 1 and 65535
+	Number uint16 `json:"number" yaml:"number"`
+
+	// Required
+	Protocol PortProtocol `json:"protocol" yaml:"protocol"`
+
+	// If non-empty, must be unique among the service's ports
+	Name string `json:"name,omitempty" yaml:"name"`
+}
+
+// Gets the port in Docker port specification syntax, e.g. "8080/tcp"
+func (port Port) String() string {
+	return fmt.Sprintf("%v/%v", port.Number, port.Protocol)
+}
+
+// Returns an error if the port's number or protocol is invalid
+func (port Port) Validate() error {
+	if port.Number == 0 {
+		return stacktrace.NewError("Port '%v' has number 0, but port numbers must be between 1 and 65535", port)
+	}
+	switch port.Protocol {
+	case TCP, UDP, SCTP:
+		return nil
+	case "":
+		return stacktrace.NewError("Port %v has no protocol; it must be one of '%v', '%v', or '%v'", port.Number, TCP, UDP, SCTP)
+	default:
+		return stacktrace.NewError(
+			"Port %v has unrecognized protocol '%v'; it must be one of '%v', '%v', or '%v'",
+			port.Number,
+			port.Protocol,
+			TCP,
+			UDP,
+			SCTP)
+	}
+}
+
+/*
+Validates each of a service's ports, and checks that no two ports have the same name or the same number & protocol
+
+Returns:
+	An error listing every problem with the ports, or nil if there are none
+ */
+func ValidatePorts(ports []Port) error {
+	problems := []string{}
+	portNames := map[string]bool{}
+	portSpecs := map[string]bool{}
+	for _, port := range ports {
+		if err := port.Validate(); err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if portSpecs[port.String()] {
+			problems = append(problems, fmt.Sprintf("Port '%v' is declared more than once", port))
+		}
+		portSpecs[port.String()] = true
+		if port.Name == "" {
+			continue
+		}
+		if portNames[port.Name] {
+			problems = append(problems, fmt.Sprintf("Port name '%v' is used by more than one port", port.Name))
+		}
+		portNames[port.Name] = true
+	}
+	if len(problems) > 0 {
+		return stacktrace.NewError("The ports are invalid:\n%v", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+/*
+Gets the port with the given name from the given ports
+
+Returns:
+	The port, or an error if none of the ports has the name
+ */
+func GetPortByName(ports []Port, name string) (Port, error) {
+	for _, port := range ports {
+		if port.Name != "" && port.Name == name {
+			return port, nil
+		}
+	}
+	return Port{}, stacktrace.NewError("No port with name '%v' exists", name)
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPortString(t *testing.T) {
+	assert.Equal(t, "8080/tcp", Port{Number: 8080, Protocol: TCP, Name: "http"}.String())
+	assert.Equal(t, "53/udp", Port{Number: 53, Protocol: UDP}.String())
+}
+
+func TestValidatePorts(t *testing.T) {
+	validPorts := []Port{
+		{Number: 8080, Protocol: TCP, Name: "http"},
+		{Number: 8080, Protocol: UDP},
+		{Number: 9000, Protocol: SCTP},
+		{Number: 65535, Protocol: TCP},
+	}
+	assert.NoError(t, ValidatePorts(validPorts))
+
+	invalidPorts := []Port{
+		{Number: 0, Protocol: TCP},
+		{Number: 80, Protocol: ""},
+		{Number: 81, Protocol: "tpc"},
+		{Number: 8080, Protocol: TCP, Name: "http"},
+		{Number: 8080, Protocol: TCP},
+		{Number: 8081, Protocol: TCP, Name: "http"},
+	}
+	err := ValidatePorts(invalidPorts)
+	assert.Error(t, err)
+	errStr := err.Error()
+	assert.Contains(t, errStr, "Port '0/tcp' has number 0")
+	assert.Contains(t, errStr, "Port 80 has no protocol")
+	assert.Contains(t, errStr, "Port 81 has unrecognized protocol 'tpc'")
+	assert.Contains(t, errStr, "Port '8080/tcp' is declared more than once")
+	assert.Contains(t, errStr, "Port name 'http' is used by more than one port")
+}
+
+func TestGetPortByName(t *testing.T) {
+	ports := []Port{
+		{Number: 8080, Protocol: TCP, Name: "http"},
+		{Number: 9090, Protocol: TCP},
+	}
+	port, err := GetPortByName(ports, "http")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the port by name"))
+	}
+	assert.Equal(t, uint16(8080), port.Number)
+
+	_, err = GetPortByName(ports, "grpc")
+	assert.Error(t, err)
+	// Unnamed ports shouldn't match the empty name
+	_, err = GetPortByName(ports, "")
+	assert.Error(t, err)
+}
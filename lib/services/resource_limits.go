@@ -0,0 +1,22 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+/*
+Constraints on the resources that a service's container can use, so that a runaway service can't starve the others
+	and so that tests can exercise behaviour under resource pressure
+The zero value of each field means "unlimited", so the zero value of the struct places no constraints on the container
+ */
+type ResourceLimits struct {
+	// CPU quota, in thousandths of a CPU (e.g. 1500 = 1.5 CPUs)
+	CpuMillicores uint64
+
+	// Maximum memory the container can use, in bytes
+	MemoryLimitBytes uint64
+
+	// Maximum number of processes the container can run
+	PidsLimit uint64
+}
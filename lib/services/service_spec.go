@@ -0,0 +1,419 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/palantir/stacktrace"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	yamlSpecFileExtension = ".yaml"
+	ymlSpecFileExtension = ".yml"
+	jsonSpecFileExtension = ".json"
+
+	defaultHttpHealthCheckStatusCode = http.StatusOK
+
+	// The special bits of a chmod-style octal mode, and all the bits such a mode may have
+	octalSetuidBit = 04000
+	octalSetgidBit = 02000
+	octalStickyBit = 01000
+	octalModeBits = 07777
+)
+
+/*
+Declares a service in a spec file, so that services can be defined without writing Go, e.g. in YAML:
+
+	services:
+	  datastore:
+	    image: kurtosistech/example-microservices_datastore
+	    ports:
+	      - name: http
+	        number: 1323
+	    env:
+	      LOG_LEVEL: debug
+	    command: ["./datastore.bin", "--bind={{.IPAddress}}", "--config={{index .GeneratedFilepaths \"config\"}}"]
+	    files:
+	      config:
+	        template: "port = {{.Values.port}}"
+	        values:
+	          port: 1323
+	      entrypoint:
+	        source: /static/datastore-entrypoint.sh
+	        mode: "0755"
+	    testVolumeMountpoint: /test-volume
+	    healthCheck:
+	      http:
+	        port: 1323
+	        path: /health
+
+The command's arguments are templates rendered with StartCommandTemplateData, and the files' templates are rendered with
+	FileTemplateData (see DockerContainerInitializerBuilder).
+ */
+type ServiceSpec struct {
+	// Required
+	Image string `json:"image" yaml:"image"`
+
+	// Ports that the container listens on, whose protocol defaults to TCP if omitted
+	Ports []Port `json:"ports" yaml:"ports"`
+
+	// Environment variables to set in the container, which are overridden by the networks.WithEnvVars option
+	Env map[string]string `json:"env" yaml:"env"`
+
+	// If empty, the container runs the command specified by its image
+	Command []string `json:"command" yaml:"command"`
+
+	// Mapping of generated file key -> the file's template
+	Files map[string]FileSpec `json:"files" yaml:"files"`
+
+	// Mapping of files artifact ID -> the directory in the container the artifact will be mounted at
+	FilesArtifacts map[FilesArtifactID]string `json:"filesArtifacts" yaml:"filesArtifacts"`
+
+	// Mapping of shared volume ID -> the directory in the container the shared volume will be mounted at
+	SharedVolumes map[SharedVolumeID]string `json:"sharedVolumes" yaml:"sharedVolumes"`
+
+	// Required
+	TestVolumeMountpoint string `json:"testVolumeMountpoint" yaml:"testVolumeMountpoint"`
+
+	// If nil, the service is considered available as soon as its container is started
+	HealthCheck *HealthCheckSpec `json:"healthCheck" yaml:"healthCheck"`
+
+	Dependencies []ServiceID `json:"dependencies" yaml:"dependencies"`
+}
+
+// A generated file, whose contents come from exactly one of the template, the literal contents, or the source path
+type FileSpec struct {
+	// Rendered when the service is started (see FileTemplate)
+	Template string `json:"template" yaml:"template"`
+
+	// User-provided values, available to the template as {{.Values.<key>}}
+	Values map[string]interface{} `json:"values" yaml:"values"`
+
+	Contents *string `json:"contents" yaml:"contents"`
+
+	// Absolute path of a file or directory in the testsuite's image to copy (see NewGeneratedFileFromTestsuitePath)
+	Source string `json:"source" yaml:"source"`
+
+	// Octal permissions like chmod's, e.g. "0600", "0755", or "4755" for setuid; if empty, templates and contents get
+	//  0644 and sources keep their own permissions
+	Mode string `json:"mode" yaml:"mode"`
+}
+
+// Selects the availability check of a ServiceSpec; exactly one of the fields must be set
+type HealthCheckSpec struct {
+	Http *HttpHealthCheckSpec `json:"http" yaml:"http"`
+
+	Tcp *TcpHealthCheckSpec `json:"tcp" yaml:"tcp"`
+
+	Grpc *GrpcHealthCheckSpec `json:"grpc" yaml:"grpc"`
+
+	Exec *ExecHealthCheckSpec `json:"exec" yaml:"exec"`
+
+	LogLine *LogLineHealthCheckSpec `json:"logLine" yaml:"logLine"`
+}
+
+// See HttpCheck
+type HttpHealthCheckSpec struct {
+	Port int `json:"port" yaml:"port"`
+
+	Path string `json:"path" yaml:"path"`
+
+	// Defaults to 200 if unset
+	ExpectedStatus int `json:"expectedStatus" yaml:"expectedStatus"`
+
+	// If empty, the body isn't checked
+	BodyRegex string `json:"bodyRegex" yaml:"bodyRegex"`
+}
+
+// See TcpCheck
+type TcpHealthCheckSpec struct {
+	Port int `json:"port" yaml:"port"`
+}
+
+// See GrpcHealthCheck
+type GrpcHealthCheckSpec struct {
+	Port int `json:"port" yaml:"port"`
+
+	// If empty, the health of the server as a whole is checked
+	Service string `json:"service" yaml:"service"`
+}
+
+// See ExecCheck
+type ExecHealthCheckSpec struct {
+	Command []string `json:"command" yaml:"command"`
+}
+
+// See LogLineCheck
+type LogLineHealthCheckSpec struct {
+	Regex string `json:"regex" yaml:"regex"`
+}
+
+// The specs declared in a spec file, keyed by spec name
+type ServiceSpecs map[string]*ServiceSpec
+
+// The top-level structure of a spec file
+type serviceSpecsFile struct {
+	Services ServiceSpecs `json:"services" yaml:"services"`
+}
+
+/*
+Loads the service specs in the given file, whose format is determined by its extension (.yaml, .yml, or .json)
+
+Returns:
+	The specs in the file, each of which has been validated
+ */
+func LoadServiceSpecs(specsFilepath string) (ServiceSpecs, error) {
+	contents, err := ioutil.ReadFile(specsFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading service specs file '%v'", specsFilepath)
+	}
+
+	var specs ServiceSpecs
+	switch extension := strings.ToLower(filepath.Ext(specsFilepath)); extension {
+	case yamlSpecFileExtension, ymlSpecFileExtension:
+		specs, err = ParseYamlServiceSpecs(contents)
+	case jsonSpecFileExtension:
+		specs, err = ParseJsonServiceSpecs(contents)
+	default:
+		return nil, stacktrace.NewError(
+			"Service specs file '%v' has unrecognized extension '%v'; expected one of %v, %v, or %v",
+			specsFilepath,
+			extension,
+			yamlSpecFileExtension,
+			ymlSpecFileExtension,
+			jsonSpecFileExtension)
+	}
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing service specs file '%v'", specsFilepath)
+	}
+	return specs, nil
+}
+
+// Parses & validates service specs from YAML, rejecting unknown fields so that typos don't go unnoticed
+func ParseYamlServiceSpecs(contents []byte) (ServiceSpecs, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	decoder.KnownFields(true)
+	specsFile := &serviceSpecsFile{}
+	if err := decoder.Decode(specsFile); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred deserializing the service specs YAML")
+	}
+	if err := specsFile.Services.validate(); err != nil {
+		return nil, stacktrace.Propagate(err, "The service specs are invalid")
+	}
+	return specsFile.Services, nil
+}
+
+// Parses & validates service specs from JSON, rejecting unknown fields so that typos don't go unnoticed
+func ParseJsonServiceSpecs(contents []byte) (ServiceSpecs, error) {
+	decoder := json.NewDecoder(bytes.NewReader(contents))
+	decoder.DisallowUnknownFields()
+	specsFile := &serviceSpecsFile{}
+	if err := decoder.Decode(specsFile); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred deserializing the service specs JSON")
+	}
+	if err := specsFile.Services.validate(); err != nil {
+		return nil, stacktrace.Propagate(err, "The service specs are invalid")
+	}
+	return specsFile.Services, nil
+}
+
+// Gets the spec with the given name, returning an error if there's no such spec
+func (specs ServiceSpecs) Get(specName string) (*ServiceSpec, error) {
+	spec, found := specs[specName]
+	if !found || spec == nil {
+		return nil, stacktrace.NewError("No service spec with name '%v' exists", specName)
+	}
+	return spec, nil
+}
+
+/*
+Creates an initializer for containers described by the spec, whose services are GenericServices
+ */
+func (spec ServiceSpec) NewInitializer() (TypedDockerContainerInitializer[*GenericService], error) {
+	usedPorts := []Port{}
+	for _, port := range spec.Ports {
+		if port.Protocol == "" {
+			port.Protocol = TCP
+		}
+		usedPorts = append(usedPorts, port)
+	}
+	builder := NewDockerContainerInitializerBuilder[*GenericService]().
+		WithDockerImage(spec.Image).
+		WithUsedPorts(usedPorts...).
+		WithTestVolumeMountpoint(spec.TestVolumeMountpoint).
+		WithServiceFactory(func(serviceId ServiceID, ipAddr string) *GenericService {
+			return NewGenericService(serviceId, ipAddr, usedPorts)
+		}).
+		WithDependencies(spec.Dependencies...)
+	if len(spec.Command) > 0 {
+		builder.WithStartCommand(spec.Command...)
+	}
+	for fileKey, fileSpec := range spec.Files {
+		if err := fileSpec.addToBuilder(fileKey, builder); err != nil {
+			return nil, stacktrace.Propagate(err, "File '%v' is invalid", fileKey)
+		}
+	}
+	for filesArtifactId, mountDirpath := range spec.FilesArtifacts {
+		builder.WithFilesArtifactMount(filesArtifactId, mountDirpath)
+	}
+	for volumeId, mountDirpath := range spec.SharedVolumes {
+		builder.WithSharedVolumeMount(volumeId, mountDirpath)
+	}
+	if spec.HealthCheck != nil {
+		availabilityCheck, err := spec.HealthCheck.newAvailabilityCheck()
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "The health check is invalid")
+		}
+		builder.WithAvailabilityCheck(availabilityCheck)
+	}
+
+	initializer, err := builder.Build()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred building the initializer for the service spec")
+	}
+	return initializer, nil
+}
+
+// ====================================================================================================
+//                                       Private helper methods
+// ====================================================================================================
+// Validates every spec, reporting the problems with all of them at once
+func (specs ServiceSpecs) validate() error {
+	specNames := []string{}
+	for specName := range specs {
+		specNames = append(specNames, specName)
+	}
+	sort.Strings(specNames)
+
+	errorStrs := []string{}
+	for _, specName := range specNames {
+		spec := specs[specName]
+		if spec == nil {
+			errorStrs = append(errorStrs, stacktrace.NewError("Service spec '%v' is empty", specName).Error())
+			continue
+		}
+		if _, err := spec.NewInitializer(); err != nil {
+			errorStrs = append(errorStrs, stacktrace.Propagate(err, "Service spec '%v' is invalid", specName).Error())
+		}
+	}
+	if len(errorStrs) > 0 {
+		return stacktrace.NewError("Errors occurred validating the service specs:\n%v", strings.Join(errorStrs, "\n"))
+	}
+	return nil
+}
+
+func (fileSpec FileSpec) addToBuilder(fileKey string, builder *DockerContainerInitializerBuilder[*GenericService]) error {
+	numContentSources := 0
+	for _, isSet := range []bool{fileSpec.Template != "", fileSpec.Contents != nil, fileSpec.Source != ""} {
+		if isSet {
+			numContentSources++
+		}
+	}
+	if numContentSources != 1 {
+		return stacktrace.NewError("Exactly one of template, contents, or source must be set, but %v were", numContentSources)
+	}
+	if fileSpec.Values != nil && fileSpec.Template == "" {
+		return stacktrace.NewError("Values can only be used with a template")
+	}
+
+	var mode os.FileMode
+	if fileSpec.Mode != "" {
+		parsedMode, err := parseOctalFileMode(fileSpec.Mode)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred parsing the file's mode")
+		}
+		mode = parsedMode
+	}
+
+	switch {
+	case fileSpec.Template != "":
+		builder.WithTemplatedFile(fileKey, fileSpec.Template, fileSpec.Values, mode)
+	case fileSpec.Contents != nil:
+		builder.WithFile(fileKey, NewGeneratedFileFromBytes([]byte(*fileSpec.Contents), mode))
+	default:
+		builder.WithFile(fileKey, NewGeneratedFileFromTestsuitePath(fileSpec.Source, mode))
+	}
+	return nil
+}
+
+/*
+Parses a chmod-style octal mode, translating the setuid, setgid, and sticky bits (04000, 02000, and 01000) to their
+	os.FileMode equivalents, which don't share chmod's bit positions
+ */
+func parseOctalFileMode(modeStr string) (os.FileMode, error) {
+	parsedMode, err := strconv.ParseUint(modeStr, 8, 32)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "Mode '%v' isn't an octal number", modeStr)
+	}
+	if parsedMode &^ octalModeBits != 0 {
+		return 0, stacktrace.NewError("Mode '%v' has bits set above %#o", modeStr, octalModeBits)
+	}
+
+	mode := os.FileMode(parsedMode) & os.ModePerm
+	if parsedMode & octalSetuidBit != 0 {
+		mode |= os.ModeSetuid
+	}
+	if parsedMode & octalSetgidBit != 0 {
+		mode |= os.ModeSetgid
+	}
+	if parsedMode & octalStickyBit != 0 {
+		mode |= os.ModeSticky
+	}
+	return mode, nil
+}
+
+func (healthCheck HealthCheckSpec) newAvailabilityCheck() (AvailabilityCheck, error) {
+	availabilityChecks := []AvailabilityCheck{}
+	if healthCheck.Http != nil {
+		expectedStatus := healthCheck.Http.ExpectedStatus
+		if expectedStatus == 0 {
+			expectedStatus = defaultHttpHealthCheckStatusCode
+		}
+		var bodyRegex *regexp.Regexp
+		if healthCheck.Http.BodyRegex != "" {
+			compiledRegex, err := regexp.Compile(healthCheck.Http.BodyRegex)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred compiling HTTP health check body regex '%v'", healthCheck.Http.BodyRegex)
+			}
+			bodyRegex = compiledRegex
+		}
+		availabilityChecks = append(availabilityChecks, NewHttpCheck(healthCheck.Http.Port, healthCheck.Http.Path, expectedStatus, bodyRegex))
+	}
+	if healthCheck.Tcp != nil {
+		availabilityChecks = append(availabilityChecks, NewTcpCheck(healthCheck.Tcp.Port))
+	}
+	if healthCheck.Grpc != nil {
+		availabilityChecks = append(availabilityChecks, NewGrpcHealthCheck(healthCheck.Grpc.Port, healthCheck.Grpc.Service))
+	}
+	if healthCheck.Exec != nil {
+		if len(healthCheck.Exec.Command) == 0 {
+			return nil, stacktrace.NewError("The exec health check has no command")
+		}
+		availabilityChecks = append(availabilityChecks, NewExecCheck(healthCheck.Exec.Command))
+	}
+	if healthCheck.LogLine != nil {
+		logLineRegex, err := regexp.Compile(healthCheck.LogLine.Regex)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred compiling log line health check regex '%v'", healthCheck.LogLine.Regex)
+		}
+		availabilityChecks = append(availabilityChecks, NewLogLineCheck(logLineRegex))
+	}
+
+	if len(availabilityChecks) != 1 {
+		return nil, stacktrace.NewError("Exactly one type of health check must be declared, but %v were", len(availabilityChecks))
+	}
+	return availabilityChecks[0], nil
+}
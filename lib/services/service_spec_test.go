@@ -0,0 +1,236 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"bytes"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+)
+
+const (
+	testYamlServiceSpecs = `
+services:
+  datastore:
+    image: test-image
+    ports:
+      - name: http
+        number: 1323
+    env:
+      LOG_LEVEL: debug
+    command: ["./datastore.bin", "--bind={{.IPAddress}}", "--config={{index .GeneratedFilepaths \"config\"}}"]
+    files:
+      config:
+        template: "port = {{.Values.port}}"
+        values:
+          port: 1323
+        mode: "0600"
+      entrypoint:
+        contents: "#!/bin/sh"
+        mode: "0755"
+    filesArtifacts:
+      artifact: /static
+    sharedVolumes:
+      keystore: /keystore
+    testVolumeMountpoint: /test-volume
+    healthCheck:
+      http:
+        port: 1323
+        path: /health
+    dependencies: [other]
+`
+
+	testJsonServiceSpecs = `{
+	"services": {
+		"nginx": {
+			"image": "nginx",
+			"ports": [{"number": 80, "protocol": "tcp"}],
+			"testVolumeMountpoint": "/test-volume",
+			"healthCheck": {"tcp": {"port": 80}}
+		}
+	}
+}`
+)
+
+func TestParseYamlServiceSpecs(t *testing.T) {
+	specs, err := ParseYamlServiceSpecs([]byte(testYamlServiceSpecs))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred parsing the specs"))
+	}
+	spec, err := specs.Get("datastore")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the spec"))
+	}
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug"}, spec.Env)
+
+	initializer, err := spec.NewInitializer()
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the initializer"))
+	}
+	assert.Equal(t, "test-image", initializer.GetDockerImage())
+	assert.Equal(t, []Port{{Number: 1323, Protocol: TCP, Name: "http"}}, initializer.GetUsedPorts())
+	assert.Equal(t, map[FilesArtifactID]string{"artifact": "/static"}, initializer.GetFilesArtifactMountpoints())
+	assert.Equal(t, map[SharedVolumeID]string{"keystore": "/keystore"}, GetInitializerSharedVolumeMountpoints(initializer))
+	assert.Equal(t, "/test-volume", initializer.GetTestVolumeMountpoint())
+	assert.Equal(t, map[ServiceID]bool{"other": true}, GetInitializerDependencies(initializer))
+	assert.IsType(t, &HttpCheck{}, GetInitializerAvailabilityCheck(initializer))
+
+	startCmd, err := initializer.GetStartCommand(map[string]string{"config": "/test-volume/config"}, "1.2.3.4")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred rendering the start command"))
+	}
+	assert.Equal(t, []string{"./datastore.bin", "--bind=1.2.3.4", "--config=/test-volume/config"}, startCmd)
+
+	buffer := &bytes.Buffer{}
+	if err := GetInitializerFileTemplates(initializer)["config"].Render(buffer, FileTemplateData{}); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred rendering the config file"))
+	}
+	assert.Equal(t, "port = 1323", buffer.String())
+	assert.Equal(t, os.FileMode(0600), GetInitializerFileTemplates(initializer)["config"].GetMode())
+
+	entrypointFilepath := path.Join(t.TempDir(), "entrypoint")
+	if err := GetInitializerGeneratedFiles(initializer)["entrypoint"].Generate(entrypointFilepath); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred generating the entrypoint file"))
+	}
+	assertFileContentsAndMode(t, entrypointFilepath, []byte("#!/bin/sh"), 0755)
+
+	service := initializer.GetService("datastore-1", "1.2.3.4")
+	assert.Equal(t, ServiceID("datastore-1"), service.GetServiceID())
+	httpPort, err := service.GetPort("http")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the service's HTTP port"))
+	}
+	assert.Equal(t, uint16(1323), httpPort.Number)
+}
+
+func TestParseJsonServiceSpecs(t *testing.T) {
+	specs, err := ParseJsonServiceSpecs([]byte(testJsonServiceSpecs))
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred parsing the specs"))
+	}
+	spec, err := specs.Get("nginx")
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred getting the spec"))
+	}
+	initializer, err := spec.NewInitializer()
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred creating the initializer"))
+	}
+	assert.IsType(t, &TcpCheck{}, GetInitializerAvailabilityCheck(initializer))
+
+	// No command was given, so the image's default command should be used
+	startCmd, err := initializer.GetStartCommand(map[string]string{}, "1.2.3.4")
+	assert.NoError(t, err)
+	assert.Nil(t, startCmd)
+
+	_, err = specs.Get("nonexistent")
+	assert.Error(t, err)
+}
+
+func TestParseServiceSpecsRejectsUnknownFields(t *testing.T) {
+	_, err := ParseYamlServiceSpecs([]byte("services:\n  nginx:\n    imag: nginx\n"))
+	assert.Error(t, err)
+
+	_, err = ParseJsonServiceSpecs([]byte(`{"services": {"nginx": {"imag": "nginx"}}}`))
+	assert.Error(t, err)
+}
+
+func TestParseServiceSpecsReportsInvalidSpecs(t *testing.T) {
+	specsYaml := `
+services:
+  no-image:
+    testVolumeMountpoint: /test-volume
+  two-file-sources:
+    image: nginx
+    testVolumeMountpoint: /test-volume
+    files:
+      config:
+        template: "{{.IPAddress}}"
+        source: /etc/config
+  bad-file-mode:
+    image: nginx
+    testVolumeMountpoint: /test-volume
+    files:
+      key:
+        contents: secret
+        mode: rw-------
+  too-big-file-mode:
+    image: nginx
+    testVolumeMountpoint: /test-volume
+    files:
+      key:
+        contents: secret
+        mode: "17777"
+  two-health-checks:
+    image: nginx
+    testVolumeMountpoint: /test-volume
+    healthCheck:
+      tcp:
+        port: 80
+      exec:
+        command: ["true"]
+`
+	_, err := ParseYamlServiceSpecs([]byte(specsYaml))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Service spec 'no-image' is invalid")
+	assert.Contains(t, err.Error(), "Service spec 'two-health-checks' is invalid")
+	assert.Contains(t, err.Error(), "Exactly one of template, contents, or source must be set, but 2 were")
+	assert.Contains(t, err.Error(), "Mode 'rw-------' isn't an octal number")
+	assert.Contains(t, err.Error(), "Mode '17777' has bits set above 07777")
+}
+
+func TestParseOctalFileMode(t *testing.T) {
+	expectedModes := map[string]os.FileMode{
+		"0644": 0644,
+		"755": 0755,
+		"4755": os.ModeSetuid | 0755,
+		"2755": os.ModeSetgid | 0755,
+		"1777": os.ModeSticky | 0777,
+		"7777": os.ModeSetuid | os.ModeSetgid | os.ModeSticky | 0777,
+	}
+	for modeStr, expectedMode := range expectedModes {
+		mode, err := parseOctalFileMode(modeStr)
+		if err != nil {
+			t.Fatal(stacktrace.Propagate(err, "An error occurred parsing mode '%v'", modeStr))
+		}
+		assert.Equal(t, expectedMode, mode, "Mode '%v' was parsed incorrectly", modeStr)
+	}
+}
+
+func TestLoadServiceSpecs(t *testing.T) {
+	tempDirpath := t.TempDir()
+
+	yamlFilepath := path.Join(tempDirpath, "specs.yml")
+	if err := ioutil.WriteFile(yamlFilepath, []byte(testYamlServiceSpecs), 0644); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the YAML specs file"))
+	}
+	yamlSpecs, err := LoadServiceSpecs(yamlFilepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred loading the YAML specs file"))
+	}
+	assert.Contains(t, yamlSpecs, "datastore")
+
+	jsonFilepath := path.Join(tempDirpath, "specs.json")
+	if err := ioutil.WriteFile(jsonFilepath, []byte(testJsonServiceSpecs), 0644); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the JSON specs file"))
+	}
+	jsonSpecs, err := LoadServiceSpecs(jsonFilepath)
+	if err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred loading the JSON specs file"))
+	}
+	assert.Contains(t, jsonSpecs, "nginx")
+
+	unknownExtensionFilepath := path.Join(tempDirpath, "specs.toml")
+	if err := ioutil.WriteFile(unknownExtensionFilepath, []byte(""), 0644); err != nil {
+		t.Fatal(stacktrace.Propagate(err, "An error occurred writing the specs file with an unknown extension"))
+	}
+	_, err = LoadServiceSpecs(unknownExtensionFilepath)
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+/*
+Error returned by WaitForServicesStartup, identifying exactly which services didn't become available and why
+ */
+type ServicesUnavailableError struct {
+	// Mapping of serviceId -> error describing why the service didn't become available
+	Failures map[ServiceID]error
+}
+
+func (err ServicesUnavailableError) Error() string {
+	serviceIdStrs := []string{}
+	for serviceId := range err.Failures {
+		serviceIdStrs = append(serviceIdStrs, string(serviceId))
+	}
+	sort.Strings(serviceIdStrs)
+
+	lines := []string{
+		fmt.Sprintf("%v service(s) did not become available: %v", len(serviceIdStrs), strings.Join(serviceIdStrs, ", ")),
+	}
+	for _, serviceIdStr := range serviceIdStrs {
+		lines = append(lines, fmt.Sprintf("Service '%v': %v", serviceIdStr, err.Failures[ServiceID(serviceIdStr)]))
+	}
+	return strings.Join(lines, "\n")
+}
+
+/*
+Waits on all the given availability checkers in parallel, so that the total wait is as long as the slowest service
+	rather than the sum of all services. All checkers share the deadline of the given context, so use
+	context.WithTimeout to bound the overall wait.
+
+Args:
+	ctx: The context whose deadline or cancellation bounds the wait for all the services
+	checkers: Mapping of serviceId -> the availability checker for that service
+	backoff: The backoff strategy used between polls by each checker
+
+Returns:
+	Nil if all services became available; otherwise a *ServicesUnavailableError identifying each service that didn't
+		become available along with the reason why
+ */
+func WaitForServicesStartup(ctx context.Context, checkers map[ServiceID]AvailabilityChecker, backoff BackoffStrategy) error {
+	waitGroup := sync.WaitGroup{}
+	failuresMutex := &sync.Mutex{}
+	failures := map[ServiceID]error{}
+	for serviceId, checker := range checkers {
+		waitGroup.Add(1)
+		go func(serviceId ServiceID, checker AvailabilityChecker) {
+			defer waitGroup.Done()
+			if err := checker.WaitForStartupWithContext(ctx, backoff); err != nil {
+				failuresMutex.Lock()
+				defer failuresMutex.Unlock()
+				failures[serviceId] = err
+			}
+		}(serviceId, checker)
+	}
+	waitGroup.Wait()
+
+	if len(failures) > 0 {
+		return &ServicesUnavailableError{Failures: failures}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWaitForServicesStartup(t *testing.T) {
+	availableServiceIds := []ServiceID{"service1", "service2", "service3"}
+	checkers := map[ServiceID]AvailabilityChecker{}
+	for _, serviceId := range availableServiceIds {
+		checkers[serviceId] = NewDefaultAvailabilityChecker(serviceId, NewMockService(serviceId, "1.2.3.4", 3))
+	}
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 5 * time.Second)
+	defer cancelFunc()
+	assert.NoError(t, WaitForServicesStartup(ctx, checkers, NewConstantBackoff(10 * time.Millisecond)))
+}
+
+func TestWaitForServicesStartupReportsFailedServices(t *testing.T) {
+	availableServiceId := ServiceID("available")
+	neverAvailableServiceId1 := ServiceID("never-available1")
+	neverAvailableServiceId2 := ServiceID("never-available2")
+	checkers := map[ServiceID]AvailabilityChecker{
+		availableServiceId: NewDefaultAvailabilityChecker(availableServiceId, NewMockService(availableServiceId, "1.2.3.4", 1)),
+		neverAvailableServiceId1: NewDefaultAvailabilityChecker(neverAvailableServiceId1, NewMockService(neverAvailableServiceId1, "1.2.3.5", 9999)),
+		neverAvailableServiceId2: NewDefaultAvailabilityChecker(neverAvailableServiceId2, NewMockService(neverAvailableServiceId2, "1.2.3.6", 9999)),
+	}
+
+	ctx, cancelFunc := context.WithTimeout(context.Background(), 300 * time.Millisecond)
+	defer cancelFunc()
+	startTime := time.Now()
+	err := WaitForServicesStartup(ctx, checkers, NewConstantBackoff(50 * time.Millisecond))
+	// The services should be waited on in parallel, so the total wait should be bounded by the single deadline
+	assert.True(t, time.Since(startTime) < 2 * time.Second)
+
+	unavailableErr, ok := err.(*ServicesUnavailableError)
+	if !ok {
+		t.Fatalf("Expected a services unavailable error, but got: %v", err)
+	}
+	assert.Equal(t, 2, len(unavailableErr.Failures))
+	assert.Contains(t, unavailableErr.Failures, neverAvailableServiceId1)
+	assert.Contains(t, unavailableErr.Failures, neverAvailableServiceId2)
+	assert.NotContains(t, unavailableErr.Failures, availableServiceId)
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+/*
+A DockerContainerInitializer parameterized with the type of service it creates, so that the service returned by
+	networks.AddTypedService doesn't need to be cast (e.g. TypedDockerContainerInitializer[*DatastoreService])
+ */
+type TypedDockerContainerInitializer[S Service] interface {
+	DockerContainerInitializerCore
+
+	/*
+		Uses the IP address of the Docker container running the service and the service ID to create the service
+
+		Args:
+			serviceId: The ID of the service being created
+			ipAddr: The IP address of the Docker container running the service
+	*/
+	GetService(serviceId ServiceID, ipAddr string) S
+}
+
+/*
+Adapts a typed initializer to the untyped DockerContainerInitializer interface, so that it can be used anywhere a
+	DockerContainerInitializer is accepted. The optional initializer methods that the typed initializer implements are
+	passed through.
+ */
+func NewUntypedDockerContainerInitializer[S Service](initializer TypedDockerContainerInitializer[S]) DockerContainerInitializer {
+	return untypedDockerContainerInitializer[S]{TypedDockerContainerInitializer: initializer}
+}
+
+type untypedDockerContainerInitializer[S Service] struct {
+	TypedDockerContainerInitializer[S]
+}
+
+func (initializer untypedDockerContainerInitializer[S]) GetService(serviceId ServiceID, ipAddr string) Service {
+	return initializer.TypedDockerContainerInitializer.GetService(serviceId, ipAddr)
+}
+
+// The optional initializer methods are forwarded explicitly, since they aren't part of the embedded interface
+func (initializer untypedDockerContainerInitializer[S]) GetResourceLimits() ResourceLimits {
+	return GetInitializerResourceLimits(initializer.TypedDockerContainerInitializer)
+}
+
+func (initializer untypedDockerContainerInitializer[S]) GetAvailabilityCheck() AvailabilityCheck {
+	return GetInitializerAvailabilityCheck(initializer.TypedDockerContainerInitializer)
+}
+
+func (initializer untypedDockerContainerInitializer[S]) GetDependencies() map[ServiceID]bool {
+	return GetInitializerDependencies(initializer.TypedDockerContainerInitializer)
+}
+
+func (initializer untypedDockerContainerInitializer[S]) GetFileTemplates() map[string]*FileTemplate {
+	return GetInitializerFileTemplates(initializer.TypedDockerContainerInitializer)
+}
+
+func (initializer untypedDockerContainerInitializer[S]) GetSharedVolumeMountpoints() map[SharedVolumeID]string {
+	return GetInitializerSharedVolumeMountpoints(initializer.TypedDockerContainerInitializer)
+}
+
+func (initializer untypedDockerContainerInitializer[S]) GetGeneratedFiles() map[string]*GeneratedFile {
+	return GetInitializerGeneratedFiles(initializer.TypedDockerContainerInitializer)
+}
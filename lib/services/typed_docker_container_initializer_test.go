@@ -0,0 +1,44 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUntypedDockerContainerInitializer(t *testing.T) {
+	typedInitializer := NewMockTypedDockerContainerInitializer()
+	untypedInitializer := NewUntypedDockerContainerInitializer[*MockService](typedInitializer)
+
+	service := untypedInitializer.GetService("test-service", "1.2.3.4")
+	castedService, ok := service.(*MockService)
+	assert.True(t, ok)
+	assert.Equal(t, ServiceID("test-service"), castedService.GetServiceID())
+	assert.Equal(t, "1.2.3.4", castedService.GetIPAddress())
+
+	// The other methods should be passed straight through
+	assert.Equal(t, typedInitializer.GetDockerImage(), untypedInitializer.GetDockerImage())
+	assert.Equal(t, typedInitializer.GetUsedPorts(), untypedInitializer.GetUsedPorts())
+}
+
+func TestUntypedDockerContainerInitializerForwardsOptionalMethods(t *testing.T) {
+	typedInitializer := NewMockTypedDockerContainerInitializer()
+	typedInitializer.ResourceLimits = ResourceLimits{MemoryLimitBytes: 256 * 1024 * 1024}
+	typedInitializer.AvailabilityCheck = NewTcpCheck(1000)
+	typedInitializer.Dependencies = map[ServiceID]bool{"dependency": true}
+	typedInitializer.FileTemplates = map[string]*FileTemplate{"config": {}}
+	typedInitializer.SharedVolumeMountpoints = map[SharedVolumeID]string{"keystore": "/keystore"}
+	typedInitializer.GeneratedFiles = map[string]*GeneratedFile{"data": NewGeneratedDirectory(map[string]*GeneratedFile{}, 0755)}
+	untypedInitializer := NewUntypedDockerContainerInitializer[*MockService](typedInitializer)
+
+	assert.Equal(t, typedInitializer.ResourceLimits, GetInitializerResourceLimits(untypedInitializer))
+	assert.Equal(t, typedInitializer.AvailabilityCheck, GetInitializerAvailabilityCheck(untypedInitializer))
+	assert.Equal(t, typedInitializer.Dependencies, GetInitializerDependencies(untypedInitializer))
+	assert.Equal(t, typedInitializer.FileTemplates, GetInitializerFileTemplates(untypedInitializer))
+	assert.Equal(t, typedInitializer.SharedVolumeMountpoints, GetInitializerSharedVolumeMountpoints(untypedInitializer))
+	assert.Equal(t, typedInitializer.GeneratedFiles, GetInitializerGeneratedFiles(untypedInitializer))
+}
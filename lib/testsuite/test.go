@@ -11,7 +11,8 @@ import (
 )
 
 /*
-An interface encapsulating a test to run against a test network.
+An interface encapsulating a test to run against a test network. Prefer implementing TypedTest, which receives the
+	network without needing to cast it, and registering it via NewUntypedTest.
  */
 type Test interface {
 	/*
@@ -23,14 +24,12 @@ type Test interface {
 	// Initializes the network to the desired state before test execution
 	Setup(networkCtx *networks.NetworkContext) (networks.Network, error)
 
-	// NOTE: if Go had generics, 'network' would be a parameterized type representing the network that this test consumes
-	// as produced by the NetworkLoader
 	/*
 	Runs test logic against the given network, with failures reported using the given context.
 
 	Args:
-		network: A user-defined representation of the network. NOTE: Because Go doesn't have generics, this will need to
-			be casted to the appropriate type.
+		network: A user-defined representation of the network. NOTE: This will need to be casted to the appropriate type;
+			implement TypedTest instead to avoid this.
 		context: The test context, which is the user's tool for making test assertions.
 	 */
 	Run(network networks.Network, testCtx TestContext)
@@ -5,7 +5,10 @@
 
 package testsuite
 
-import "github.com/kurtosis-tech/kurtosis-go/lib/services"
+import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+)
 
 /*
 Holds configuration values that, if set, give the test the ability to do special things
@@ -21,4 +24,17 @@ type TestConfiguration struct {
 	// A mapping of ID -> URL where the artifact containing files should be downloaded from
 	// The ID is the ID that service initializers will use when requesting to use the artifact
 	FilesArtifactUrls map[services.FilesArtifactID]string
+
+	// A mapping of ID -> absolute path on the testsuite container of a directory or TAR archive (.tar, .tgz, or .tar.gz)
+	//  that will be packed onto the suite execution volume and mounted exactly like an artifact from FilesArtifactUrls
+	// IDs must not also be declared in FilesArtifactUrls, and can't contain path separators
+	LocalFilesArtifacts map[services.FilesArtifactID]string
+
+	// Paths inside every service's container that will be captured when the test fails, in addition to each service's
+	//  logs and container info (e.g. "/var/log/myservice")
+	DiagnosticsContainerPaths []string
+
+	// If non-nil, the availability of every service will be probed in the background while the test's Run method
+	//  executes, and all changes in service health will be logged after the test finishes
+	HealthMonitorConfig *networks.HealthMonitorConfig
 }
@@ -8,7 +8,18 @@ package testsuite
 /*
 An object that will be passed in to every test, which the user can use to manipulate the results of the test
  */
-type TestContext struct {}
+type TestContext struct {
+	// Receives failures reported from goroutines other than the one running the test
+	// This is a pointer-like type, so copies of the TestContext share it
+	asyncFailureChan chan error
+}
+
+func NewTestContext() TestContext {
+	return TestContext{
+		// Only the first async failure matters, since it fails the test
+		asyncFailureChan: make(chan error, 1),
+	}
+}
 
 /*
 Fails the test with the given error
@@ -30,3 +41,24 @@ func (context TestContext) AssertTrue(condition bool, err error) {
 func failTest(err error) {
 	panic(err)
 }
+
+/*
+Fails the test with the given error from a goroutine other than the one running the test (e.g. a background monitor),
+	where panicking via Fatal would crash the testsuite instead of failing the test.
+The test's Run method is not interrupted, but its result will be ignored and the test will be marked as failed.
+NOTE: Only the first async failure is kept; later ones are dropped.
+ */
+func (context TestContext) FatalAsync(err error) {
+	select {
+	case context.asyncFailureChan <- err:
+	default:
+		// A failure has already been recorded (or this context wasn't created with NewTestContext)
+	}
+}
+
+/*
+Returns the channel that failures reported via FatalAsync are sent on; this is used by the test executor
+ */
+func (context TestContext) GetAsyncFailureChan() <-chan error {
+	return context.asyncFailureChan
+}
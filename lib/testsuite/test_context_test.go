@@ -27,3 +27,19 @@ func TestFatalOnAssertion(t *testing.T) {
 	}()
 	TestContext{}.AssertTrue(false, stacktrace.NewError("Failed assertion"))
 }
+
+func TestFatalAsyncKeepsFirstFailure(t *testing.T) {
+	testCtx := NewTestContext()
+	firstErr := stacktrace.NewError("First error")
+	testCtx.FatalAsync(firstErr)
+	testCtx.FatalAsync(stacktrace.NewError("Second error"))
+
+	select {
+	case receivedErr := <- testCtx.GetAsyncFailureChan():
+		if receivedErr != firstErr {
+			t.Fatalf("Expected the first async failure to be kept, but got: %v", receivedErr)
+		}
+	default:
+		t.Fatal("No async failure was recorded")
+	}
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package testsuite
+
+import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/networks"
+	"github.com/palantir/stacktrace"
+	"time"
+)
+
+/*
+A Test parameterized with the type of network its Setup creates and its Run consumes (e.g. TypedTest[*TestNetwork]),
+	so that Run doesn't need to cast the network. Register it in TestSuite.GetTests via NewUntypedTest.
+ */
+type TypedTest[N networks.Network] interface {
+	// See Test.GetTestConfiguration
+	GetTestConfiguration() TestConfiguration
+
+	// Initializes the network to the desired state before test execution
+	Setup(networkCtx *networks.NetworkContext) (N, error)
+
+	/*
+	Runs test logic against the network created by Setup, with failures reported using the given context.
+
+	Args:
+		network: The network returned by Setup
+		testCtx: The test context, which is the user's tool for making test assertions.
+	 */
+	Run(network N, testCtx TestContext)
+
+	// See Test.GetExecutionTimeout
+	GetExecutionTimeout() time.Duration
+
+	// See Test.GetSetupTeardownBuffer
+	GetSetupTeardownBuffer() time.Duration
+}
+
+/*
+Adapts a typed test to the untyped Test interface, so that it can be registered in TestSuite.GetTests alongside
+	untyped tests
+ */
+func NewUntypedTest[N networks.Network](test TypedTest[N]) Test {
+	return untypedTest[N]{TypedTest: test}
+}
+
+type untypedTest[N networks.Network] struct {
+	TypedTest[N]
+}
+
+func (test untypedTest[N]) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+	network, err := test.TypedTest.Setup(networkCtx)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred setting up the typed test's network")
+	}
+	return network, nil
+}
+
+func (test untypedTest[N]) Run(network networks.Network, testCtx TestContext) {
+	castedNetwork, ok := network.(N)
+	if !ok {
+		testCtx.Fatal(stacktrace.NewError("Network was of type %T rather than the type created by the test's Setup; this is a bug in Kurtosis", network))
+		return
+	}
+	test.TypedTest.Run(castedNetwork, testCtx)
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package testsuite
+
+import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/networks"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type testTypedNetwork struct {
+	name string
+}
+
+type testTypedTest struct {
+	receivedNetwork *testTypedNetwork
+}
+
+func (test *testTypedTest) GetTestConfiguration() TestConfiguration {
+	return TestConfiguration{}
+}
+
+func (test *testTypedTest) Setup(networkCtx *networks.NetworkContext) (*testTypedNetwork, error) {
+	return &testTypedNetwork{name: "typed-network"}, nil
+}
+
+func (test *testTypedTest) Run(network *testTypedNetwork, testCtx TestContext) {
+	test.receivedNetwork = network
+}
+
+func (test *testTypedTest) GetExecutionTimeout() time.Duration {
+	return time.Second
+}
+
+func (test *testTypedTest) GetSetupTeardownBuffer() time.Duration {
+	return time.Second
+}
+
+func TestUntypedTestAdapter(t *testing.T) {
+	typedTest := &testTypedTest{}
+	var untypedTest Test = NewUntypedTest[*testTypedNetwork](typedTest)
+
+	network, err := untypedTest.Setup(nil)
+	assert.NoError(t, err)
+	untypedTest.Run(network, NewTestContext())
+	assert.Equal(t, "typed-network", typedTest.receivedNetwork.name)
+	assert.Equal(t, time.Second, untypedTest.GetExecutionTimeout())
+}
+
+func TestUntypedTestAdapterFailsOnWrongNetworkType(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("The code did not panic when it should")
+		}
+	}()
+	untypedTest := NewUntypedTest[*testTypedNetwork](&testTypedTest{})
+	untypedTest.Run("not a typed network", NewTestContext())
+}
@@ -95,10 +95,13 @@ if "${do_run}"; then
     # NOTE: Replace these with whatever custom properties your service needs
     api_service_image="${KURTOSIS_DOCKERHUB_ORG}/example-microservices_api"
     datastore_service_image="${KURTOSIS_DOCKERHUB_ORG}/example-microservices_datastore"
+    # Set DEBUG_HOLD_DURATION (e.g. "10m") to keep a failed test's network alive for investigation
+    debug_hold_duration="${DEBUG_HOLD_DURATION:-}"
     custom_params_json='{
         "apiServiceImage" :"'${api_service_image}'",
         "datastoreServiceImage": "'${datastore_service_image}'",
-        "isKurtosisCoreDevMode": '${IS_KURTOSIS_CORE_DEV_MODE}'
+        "isKurtosisCoreDevMode": '${IS_KURTOSIS_CORE_DEV_MODE}',
+        "debugHoldDuration": "'${debug_hold_duration}'"
     }'
     # ====================================== End custom Docker environment variables =====================================================
     # The funky ${1+"${@}"} incantation is how you you feed arguments exactly as-is to a child script in Bash
@@ -11,4 +11,7 @@ type ExampleTestsuiteArgs struct {
 
 	// Indicates that this testsuite is being run as part of CI testing in Kurtosis Core
 	IsKurtosisCoreDevMode bool		`json:"isKurtosisCoreDevMode"`
+
+	// If set (e.g. "10m"), enables debug mode, keeping a failed test's network alive for up to this long
+	DebugHoldDuration string		`json:"debugHoldDuration"`
 }
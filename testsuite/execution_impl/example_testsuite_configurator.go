@@ -12,6 +12,7 @@ import (
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
 	"strings"
+	"time"
 )
 
 type ExampleTestsuiteConfigurator struct {}
@@ -48,6 +49,27 @@ func (t ExampleTestsuiteConfigurator) ParseParamsAndCreateSuite(paramsJsonStr st
 	return suite, nil
 }
 
+// Implements execution.DebugModeTestSuiteConfigurator, so that the example testsuite supports debug mode
+func (t ExampleTestsuiteConfigurator) ParseDebugHoldDuration(paramsJsonStr string) (time.Duration, error) {
+	paramsJsonBytes := []byte(paramsJsonStr)
+	var args ExampleTestsuiteArgs
+	if err := json.Unmarshal(paramsJsonBytes, &args); err != nil {
+		return 0, stacktrace.Propagate(err, "An error occurred deserializing the testsuite params JSON")
+	}
+
+	if args.DebugHoldDuration == "" {
+		return 0, nil
+	}
+	debugHoldDuration, err := time.ParseDuration(args.DebugHoldDuration)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "An error occurred parsing debug hold duration '%v'", args.DebugHoldDuration)
+	}
+	if debugHoldDuration < 0 {
+		return 0, stacktrace.NewError("Debug hold duration '%v' is negative", args.DebugHoldDuration)
+	}
+	return debugHoldDuration, nil
+}
+
 func validateArgs(args ExampleTestsuiteArgs) error {
 	if strings.TrimSpace(args.ApiServiceImage) == "" {
 		return stacktrace.NewError("API service image is empty")
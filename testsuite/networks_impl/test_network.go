@@ -6,6 +6,7 @@
 package networks_impl
 
 import (
+	"context"
 	"github.com/kurtosis-tech/kurtosis-go/lib/networks"
 	"github.com/kurtosis-tech/kurtosis-go/lib/services"
 	"github.com/kurtosis-tech/kurtosis-go/testsuite/services_impl/api"
@@ -19,8 +20,9 @@ const (
 	datastoreServiceId services.ServiceID = "datastore"
 	apiServiceIdPrefix = "api-"
 
-	waitForStartupTimeBetweenPolls = 1 * time.Second
-	waitForStartupMaxNumPolls = 30
+	// How long a single bring-up, including waiting for every service to become available, may take
+	startupTimeout = 30 * time.Second
+	timeBetweenStartupPolls = 1 * time.Second
 )
 
 type TestNetwork struct {
@@ -43,47 +45,56 @@ func NewTestNetwork(networkCtx *networks.NetworkContext, datastoreServiceImage s
 	}
 }
 
-func (network *TestNetwork) AddDatastore() error {
-	if (network.datastoreService != nil) {
-		return stacktrace.NewError("Cannot add datastore service to network; datastore already exists!")
+/*
+Brings up the datastore and the given number of API services in one go; the API services declare their dependency
+	on the datastore, so they're only started once it's available
+
+Returns:
+	The IDs of the API services, in the order they were numbered
+ */
+func (network *TestNetwork) AddDatastoreAndApiServices(numApiServices int) ([]services.ServiceID, error) {
+	if network.datastoreService != nil {
+		return nil, stacktrace.NewError("Cannot add datastore service to network; datastore already exists!")
 	}
 
-	initializer := datastore.NewDatastoreContainerInitializer(network.datastoreServiceImage)
-	uncastedDatastore, checker, err := network.networkCtx.AddService(datastoreServiceId, initializer)
-	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred adding the datastore service")
+	initializers := map[services.ServiceID]services.DockerContainerInitializer{
+		datastoreServiceId: services.NewUntypedDockerContainerInitializer[*datastore.DatastoreService](
+			datastore.NewDatastoreContainerInitializer(network.datastoreServiceImage)),
 	}
-	if err := checker.WaitForStartup(waitForStartupTimeBetweenPolls, waitForStartupMaxNumPolls); err != nil {
-		return stacktrace.Propagate(err, "An error occurred waiting for the datastore service to start")
+	apiServiceIds := []services.ServiceID{}
+	for i := 0; i < numApiServices; i++ {
+		serviceId, initializer, err := network.newApiServiceInitializer()
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred creating the initializer for API service %v", i)
+		}
+		initializers[serviceId] = initializer
+		apiServiceIds = append(apiServiceIds, serviceId)
 	}
-	castedDatastore := uncastedDatastore.(*datastore.DatastoreService)
-	network.datastoreService = castedDatastore
-	return nil
+
+	if err := network.addServicesInDependencyOrder(initializers); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred bringing up the datastore and API services")
+	}
+	return apiServiceIds, nil
 }
 
 func (network *TestNetwork) GetDatastore() *datastore.DatastoreService {
 	return network.datastoreService
 }
 
+/*
+Adds another API service, which fails if the datastore it depends on hasn't been added yet
+ */
 func (network *TestNetwork) AddApiService() (services.ServiceID, error) {
-	if (network.datastoreService == nil) {
-		return "", stacktrace.NewError("Cannot add API service to network; no datastore service exists")
-	}
-
-	serviceIdStr := apiServiceIdPrefix + strconv.Itoa(network.nextApiServiceId)
-	network.nextApiServiceId = network.nextApiServiceId + 1
-	serviceId := services.ServiceID(serviceIdStr)
-
-	initializer := api.NewApiContainerInitializer(network.apiServiceImage, network.datastoreService)
-	uncastedApiService, checker, err := network.networkCtx.AddService(serviceId, initializer)
+	serviceId, initializer, err := network.newApiServiceInitializer()
 	if err != nil {
-		return "", stacktrace.Propagate(err, "An error occurred adding the API service")
+		return "", stacktrace.Propagate(err, "An error occurred creating the API service's initializer")
 	}
-	if err := checker.WaitForStartup(waitForStartupTimeBetweenPolls, waitForStartupMaxNumPolls); err != nil {
-		return "", stacktrace.Propagate(err, "An error occurred waiting for the API service to start")
+	initializers := map[services.ServiceID]services.DockerContainerInitializer{
+		serviceId: initializer,
+	}
+	if err := network.addServicesInDependencyOrder(initializers); err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred adding the API service")
 	}
-	castedApiService := uncastedApiService.(*api.ApiService)
-	network.apiServices[serviceId] = castedApiService
 	return serviceId, nil
 }
 
@@ -94,3 +105,43 @@ func (network *TestNetwork) GetApiService(serviceId services.ServiceID) (*api.Ap
 	}
 	return service, nil
 }
+
+// ====================================================================================================
+//                                       Private helper methods
+// ====================================================================================================
+func (network *TestNetwork) newApiServiceInitializer() (services.ServiceID, services.DockerContainerInitializer, error) {
+	serviceId := services.ServiceID(apiServiceIdPrefix + strconv.Itoa(network.nextApiServiceId))
+	network.nextApiServiceId = network.nextApiServiceId + 1
+
+	initializer, err := api.NewApiContainerInitializer(network.apiServiceImage, datastoreServiceId)
+	if err != nil {
+		return "", nil, stacktrace.Propagate(err, "An error occurred creating the initializer for API service '%v'", serviceId)
+	}
+	return serviceId, services.NewUntypedDockerContainerInitializer[*api.ApiService](initializer), nil
+}
+
+// Adds the given services, waiting until they're all available, and records the ones this network knows about
+func (network *TestNetwork) addServicesInDependencyOrder(initializers map[services.ServiceID]services.DockerContainerInitializer) error {
+	ctx, cancelFunc := context.WithTimeout(context.Background(), startupTimeout)
+	defer cancelFunc()
+	if _, _, err := network.networkCtx.AddServicesInDependencyOrder(ctx, initializers, services.NewConstantBackoff(timeBetweenStartupPolls)); err != nil {
+		return stacktrace.Propagate(err, "An error occurred adding services in dependency order")
+	}
+
+	for serviceId := range initializers {
+		if serviceId == datastoreServiceId {
+			datastoreService, err := networks.GetTypedService[*datastore.DatastoreService](network.networkCtx, serviceId)
+			if err != nil {
+				return stacktrace.Propagate(err, "An error occurred getting the datastore service")
+			}
+			network.datastoreService = datastoreService
+			continue
+		}
+		apiService, err := networks.GetTypedService[*api.ApiService](network.networkCtx, serviceId)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred getting API service '%v'", serviceId)
+		}
+		network.apiServices[serviceId] = apiService
+	}
+	return nil
+}
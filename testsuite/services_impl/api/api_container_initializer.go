@@ -6,12 +6,10 @@
 package api
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/kurtosis-tech/kurtosis-go/lib/services"
 	"github.com/kurtosis-tech/kurtosis-go/testsuite/services_impl/datastore"
 	"github.com/palantir/stacktrace"
-	"github.com/sirupsen/logrus"
 	"os"
 )
 
@@ -23,66 +21,78 @@ const (
 	testVolumeMountpoint = "/test-volume"
 )
 
-// Fields are public so we can marshal them as JSON
-type config struct {
-	DatastoreIp string	`json:"datastoreIp"`
-	DatastorePort int	`json:"datastorePort"`
-}
+/*
+The API's JSON config file, whose references to the datastore service are resolved by Kurtosis when the API service
+	is started
+ */
+const configTemplateFormatStr = `{
+	"datastoreIp": "${service:%v:ip}",
+	"datastorePort": ${service:%v:port:%v}
+}`
 
 type ApiContainerInitializer struct {
 	dockerImage string
-	datastore *datastore.DatastoreService
+	datastoreServiceId services.ServiceID
+	configTemplate *services.FileTemplate
 }
 
-func NewApiContainerInitializer(dockerImage string, datastore *datastore.DatastoreService) *ApiContainerInitializer {
-	return &ApiContainerInitializer{dockerImage: dockerImage, datastore: datastore}
+/*
+Args:
+	dockerImage: The API's Docker image
+	datastoreServiceId: The ID of the datastore service the API stores its data in, which must be added to the
+		network before the API
+ */
+func NewApiContainerInitializer(dockerImage string, datastoreServiceId services.ServiceID) (*ApiContainerInitializer, error) {
+	configTemplateStr := fmt.Sprintf(configTemplateFormatStr, datastoreServiceId, datastoreServiceId, datastore.HttpPortName)
+	configTemplate, err := services.NewFileTemplate(configTemplateStr, nil, 0)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the template of the API's config file")
+	}
+	return &ApiContainerInitializer{
+		dockerImage:        dockerImage,
+		datastoreServiceId: datastoreServiceId,
+		configTemplate:     configTemplate,
+	}, nil
 }
 
 func (initializer ApiContainerInitializer) GetDockerImage() string {
 	return initializer.dockerImage
 }
 
-func (initializer ApiContainerInitializer) GetUsedPorts() map[string]bool {
-	return map[string]bool{
-		fmt.Sprintf("%v/tcp", port): true,
+func (initializer ApiContainerInitializer) GetUsedPorts() []services.Port {
+	return []services.Port{
+		{Number: port, Protocol: services.TCP, Name: httpPortName},
 	}
 }
 
-func (initializer ApiContainerInitializer) GetService(serviceId services.ServiceID, ipAddr string) services.Service {
-	return NewApiService(serviceId, ipAddr, port)
+func (initializer ApiContainerInitializer) GetService(serviceId services.ServiceID, ipAddr string) *ApiService {
+	return NewApiService(serviceId, ipAddr, initializer.GetUsedPorts())
 }
 
 func (initializer ApiContainerInitializer) GetFilesToMount() map[string]bool {
-	return map[string]bool{
-		configFileKey: true,
-	}
+	return map[string]bool{}
 }
 
 func (initializer ApiContainerInitializer) InitializeMountedFiles(mountedFiles map[string]*os.File) error {
-	logrus.Debugf("Datastore IP: %v , port: %v", initializer.datastore.GetIPAddress(), initializer.datastore.GetPort())
-	configObj := config{
-		DatastoreIp:   initializer.datastore.GetIPAddress(),
-		DatastorePort: initializer.datastore.GetPort(),
-	}
-	configBytes, err := json.Marshal(configObj)
-	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred serializing the config to JSON")
-	}
-
-	logrus.Debugf("API config JSON: %v", string(configBytes))
+	return nil
+}
 
-	configFp := mountedFiles[configFileKey]
-	if _, err := configFp.Write(configBytes); err != nil {
-		return stacktrace.Propagate(err, "An error occurred writing the serialized config JSON to file")
+func (initializer ApiContainerInitializer) GetFileTemplates() map[string]*services.FileTemplate {
+	return map[string]*services.FileTemplate{
+		configFileKey: initializer.configTemplate,
 	}
-
-	return nil
 }
 
 func (initializer ApiContainerInitializer) GetFilesArtifactMountpoints() map[services.FilesArtifactID]string {
 	return map[services.FilesArtifactID]string{}
 }
 
+func (initializer ApiContainerInitializer) GetDependencies() map[services.ServiceID]bool {
+	return map[services.ServiceID]bool{
+		initializer.datastoreServiceId: true,
+	}
+}
+
 func (initializer ApiContainerInitializer) GetTestVolumeMountpoint() string {
 	return testVolumeMountpoint
 }
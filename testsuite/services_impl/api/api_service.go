@@ -13,6 +13,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -27,6 +29,9 @@ const (
 
 	// How long to wait before timing out HTTP requests
 	timeoutSeconds = 3 * time.Second
+
+	// Name of the port, declared by ApiContainerInitializer.GetUsedPorts, that the API serves HTTP on
+	httpPortName = "http"
 )
 
 type Person struct {
@@ -36,11 +41,11 @@ type Person struct {
 type ApiService struct {
 	serviceId services.ServiceID
 	ipAddr string
-	port int
+	usedPorts []services.Port
 }
 
-func NewApiService(serviceId services.ServiceID, ipAddr string, port int) *ApiService {
-	return &ApiService{serviceId: serviceId, ipAddr: ipAddr, port: port}
+func NewApiService(serviceId services.ServiceID, ipAddr string, usedPorts []services.Port) *ApiService {
+	return &ApiService{serviceId: serviceId, ipAddr: ipAddr, usedPorts: usedPorts}
 }
 
 // ===========================================================================================
@@ -55,7 +60,11 @@ func (service ApiService) GetIPAddress() string {
 }
 
 func (service ApiService) IsAvailable() bool {
-	url := fmt.Sprintf("http://%v:%v/%v", service.GetIPAddress(), service.port, healthcheckUrlSlug)
+	url, err := service.getUrl(healthcheckUrlSlug)
+	if err != nil {
+		logrus.Debugf("An error occurred getting the URL of the health endpoint: %v", err)
+		return false
+	}
 	resp, err := http.Get(url)
 	if err != nil {
 		logrus.Debugf("An HTTP error occurred when polliong the health endpoint: %v", err)
@@ -82,12 +91,11 @@ func (service ApiService) IsAvailable() bool {
 // ===========================================================================================
 //                         API service-specific methods
 // ===========================================================================================
-func (service ApiService) getPersonUrlForId(id int) string {
-	return fmt.Sprintf("http://%v:%v/%v/%v", service.ipAddr, service.port, personEndpoint, id)
-}
-
 func (service ApiService) AddPerson(id int) error {
-	url := service.getPersonUrlForId(id)
+	url, err := service.getUrl(personEndpoint, strconv.Itoa(id))
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the URL of person with ID '%v'", id)
+	}
 	resp, err := http.Post(url, textContentType, nil)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred making the request to add person with ID '%v'", id)
@@ -99,7 +107,10 @@ func (service ApiService) AddPerson(id int) error {
 }
 
 func (service ApiService) GetPerson(id int) (Person, error) {
-	url := service.getPersonUrlForId(id)
+	url, err := service.getUrl(personEndpoint, strconv.Itoa(id))
+	if err != nil {
+		return Person{}, stacktrace.Propagate(err, "An error occurred getting the URL of person with ID '%v'", id)
+	}
 	resp, err := http.Get(url)
 	if err != nil {
 		return Person{}, stacktrace.Propagate(err, "An error occurred making the request to get person with ID '%v'", id)
@@ -122,7 +133,10 @@ func (service ApiService) GetPerson(id int) (Person, error) {
 }
 
 func (service ApiService) IncrementBooksRead(id int) error {
-	url := fmt.Sprintf("http://%v:%v/%v/%v", service.ipAddr, service.port, incrementBooksReadEndpoint, id)
+	url, err := service.getUrl(incrementBooksReadEndpoint, strconv.Itoa(id))
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the URL for incrementing the books read of person with ID '%v'", id)
+	}
 	resp, err := http.Post(url, textContentType, nil)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred making the request to increment the books read of person with ID '%v'", id)
@@ -132,3 +146,12 @@ func (service ApiService) IncrementBooksRead(id int) error {
 	}
 	return nil
 }
+
+// Gets the URL of the given path on the API's HTTP port
+func (service ApiService) getUrl(pathElems ...string) (string, error) {
+	port, err := services.GetPortByName(service.usedPorts, httpPortName)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred getting the API's HTTP port")
+	}
+	return fmt.Sprintf("http://%v:%v/%v", service.ipAddr, port.Number, strings.Join(pathElems, "/")), nil
+}
@@ -6,9 +6,10 @@
 package datastore
 
 import (
-	"fmt"
 	"github.com/kurtosis-tech/kurtosis-go/lib/services"
+	"net/http"
 	"os"
+	"regexp"
 )
 
 const (
@@ -29,14 +30,14 @@ func (d DatastoreContainerInitializer) GetDockerImage() string {
 	return d.dockerImage
 }
 
-func (d DatastoreContainerInitializer) GetUsedPorts() map[string]bool {
-	return map[string]bool{
-		fmt.Sprintf("%v/tcp", port): true,
+func (d DatastoreContainerInitializer) GetUsedPorts() []services.Port {
+	return []services.Port{
+		{Number: port, Protocol: services.TCP, Name: HttpPortName},
 	}
 }
 
-func (d DatastoreContainerInitializer) GetService(serviceId services.ServiceID, ipAddr string) services.Service {
-	return NewDatastoreService(serviceId, ipAddr, port)
+func (d DatastoreContainerInitializer) GetService(serviceId services.ServiceID, ipAddr string) *DatastoreService {
+	return NewDatastoreService(serviceId, ipAddr, d.GetUsedPorts())
 }
 
 func (d DatastoreContainerInitializer) GetFilesToMount() map[string]bool {
@@ -51,6 +52,11 @@ func (d DatastoreContainerInitializer) GetFilesArtifactMountpoints() map[service
 	return map[services.FilesArtifactID]string{}
 }
 
+func (d DatastoreContainerInitializer) GetAvailabilityCheck() services.AvailabilityCheck {
+	healthyBodyRegex := regexp.MustCompile("^" + regexp.QuoteMeta(healthyValue) + "$")
+	return services.NewHttpCheck(port, healthcheckUrlSlug, http.StatusOK, healthyBodyRegex)
+}
+
 func (d DatastoreContainerInitializer) GetTestVolumeMountpoint() string {
 	return testVolumeMountpoint
 }
@@ -21,16 +21,19 @@ const (
 
 	textContentType = "text/plain"
 	keyEndpoint = "key"
+
+	// Name of the port, declared by DatastoreContainerInitializer.GetUsedPorts, that the datastore serves HTTP on
+	HttpPortName = "http"
 )
 
 type DatastoreService struct {
 	serviceId services.ServiceID
 	ipAddr string
-	port int
+	usedPorts []services.Port
 }
 
-func NewDatastoreService(serviceId services.ServiceID, ipAddr string, port int) *DatastoreService {
-	return &DatastoreService{serviceId: serviceId, ipAddr: ipAddr, port: port}
+func NewDatastoreService(serviceId services.ServiceID, ipAddr string, usedPorts []services.Port) *DatastoreService {
+	return &DatastoreService{serviceId: serviceId, ipAddr: ipAddr, usedPorts: usedPorts}
 }
 
 // ===========================================================================================
@@ -46,7 +49,11 @@ func (service DatastoreService) GetIPAddress() string {
 
 
 func (service DatastoreService) IsAvailable() bool {
-	url := fmt.Sprintf("http://%v:%v/%v", service.GetIPAddress(), service.port, healthcheckUrlSlug)
+	url, err := service.getUrl(healthcheckUrlSlug)
+	if err != nil {
+		logrus.Debugf("An error occurred getting the URL of the health endpoint: %v", err)
+		return false
+	}
 	resp, err := http.Get(url)
 	if err != nil {
 		logrus.Debugf("An HTTP error occurred when polliong the health endpoint: %v", err)
@@ -73,12 +80,19 @@ func (service DatastoreService) IsAvailable() bool {
 // ===========================================================================================
 //                         Datastore service-specific methods
 // ===========================================================================================
-func (service DatastoreService) GetPort() int {
-	return service.port
+func (service DatastoreService) GetPort() (int, error) {
+	port, err := services.GetPortByName(service.usedPorts, HttpPortName)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "An error occurred getting the datastore's HTTP port")
+	}
+	return int(port.Number), nil
 }
 
 func (service DatastoreService) Exists(key string) (bool, error) {
-	url := service.getUrlForKey(key)
+	url, err := service.getUrl(keyEndpoint, key)
+	if err != nil {
+		return false, stacktrace.Propagate(err, "An error occurred getting the URL for key '%v'", key)
+	}
 	resp, err := http.Get(url)
 	if err != nil {
 		return false, stacktrace.Propagate(err, "An error occurred requesting data for key '%v'", key)
@@ -93,7 +107,10 @@ func (service DatastoreService) Exists(key string) (bool, error) {
 }
 
 func (service DatastoreService) Get(key string) (string, error) {
-	url := service.getUrlForKey(key)
+	url, err := service.getUrl(keyEndpoint, key)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred getting the URL for key '%v'", key)
+	}
 	resp, err := http.Get(url)
 	if err != nil {
 		return "", stacktrace.Propagate(err, "An error occurred requesting data for key '%v'", key)
@@ -112,7 +129,10 @@ func (service DatastoreService) Get(key string) (string, error) {
 }
 
 func (service DatastoreService) Upsert(key string, value string) error {
-	url := service.getUrlForKey(key)
+	url, err := service.getUrl(keyEndpoint, key)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the URL for key '%v'", key)
+	}
 	resp, err := http.Post(url, textContentType, strings.NewReader(value))
 	if err != nil {
 		return stacktrace.Propagate(err, "An error requesting to upsert data '%v' to key '%v'", value, key)
@@ -123,8 +143,13 @@ func (service DatastoreService) Upsert(key string, value string) error {
 	return nil
 }
 
-func (service DatastoreService) getUrlForKey(key string) string {
-	return fmt.Sprintf("http://%v:%v/%v/%v", service.GetIPAddress(), service.port, keyEndpoint, key)
+// Gets the URL of the given path on the datastore's HTTP port
+func (service DatastoreService) getUrl(pathElems ...string) (string, error) {
+	port, err := service.GetPort()
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred getting the datastore's port")
+	}
+	return fmt.Sprintf("http://%v:%v/%v", service.GetIPAddress(), port, strings.Join(pathElems, "/")), nil
 }
 
 
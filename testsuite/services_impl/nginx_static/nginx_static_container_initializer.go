@@ -8,7 +8,10 @@ package nginx_static
 import (
 	"github.com/kurtosis-tech/kurtosis-go/lib/services"
 	"os"
-	"strconv"
+)
+
+const (
+	listenPort = 8080
 )
 
 /*
@@ -27,9 +30,9 @@ func (s NginxStaticContainerInitializer) GetDockerImage() string {
 	return dockerImage
 }
 
-func (s NginxStaticContainerInitializer) GetUsedPorts() map[string]bool {
-	return map[string]bool{
-		strconv.Itoa(listenPort): true,
+func (s NginxStaticContainerInitializer) GetUsedPorts() []services.Port {
+	return []services.Port{
+		{Number: listenPort, Protocol: services.TCP, Name: listenPortName},
 	}
 }
 
@@ -37,6 +40,7 @@ func (s NginxStaticContainerInitializer) GetService(serviceId services.ServiceID
 	return &NginxStaticService{
 		serviceId: serviceId,
 		ipAddr:    ipAddr,
+		usedPorts: s.GetUsedPorts(),
 	}
 }
 
@@ -56,6 +60,11 @@ func (s NginxStaticContainerInitializer) GetFilesArtifactMountpoints() map[servi
 	}
 }
 
+func (s NginxStaticContainerInitializer) GetAvailabilityCheck() services.AvailabilityCheck {
+	// Nginx is available as soon as it's accepting connections on its listen port
+	return services.NewTcpCheck(listenPort)
+}
+
 func (s NginxStaticContainerInitializer) GetTestVolumeMountpoint() string {
 	return "/test-volume"
 }
@@ -14,7 +14,8 @@ import (
 )
 
 const (
-	listenPort = 8080
+	// Name of the port, declared by NginxStaticContainerInitializer.GetUsedPorts, that Nginx serves files on
+	listenPortName = "http"
 
 	dockerImage = "flashspys/nginx-static"
 
@@ -27,6 +28,7 @@ An Nginx service that serves files mounted in the /static directory
 type NginxStaticService struct {
 	serviceId services.ServiceID
 	ipAddr    string
+	usedPorts []services.Port
 }
 
 func (n NginxStaticService) GetServiceID() services.ServiceID {
@@ -38,12 +40,24 @@ func (n NginxStaticService) GetIPAddress() string {
 }
 
 func (n NginxStaticService) IsAvailable() bool {
-	_, err := http.Get(fmt.Sprintf("%v:%v", n.ipAddr, listenPort))
-	return err != nil
+	url, err := n.getUrl("")
+	if err != nil {
+		return false
+	}
+	resp, err := http.Get(url)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
 }
 
 func (n NginxStaticService) GetFileContents(filename string) (string, error) {
-	resp, err := http.Get(fmt.Sprintf("%v:%v/%v", n.ipAddr, listenPort, filename))
+	url, err := n.getUrl(filename)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred getting the URL of file '%v'", filename)
+	}
+	resp, err := http.Get(url)
 	if err != nil {
 		return "", stacktrace.Propagate(err, "An error occurred getting the contents of file '%v'", filename)
 	}
@@ -59,3 +73,11 @@ func (n NginxStaticService) GetFileContents(filename string) (string, error) {
 	return bodyStr, nil
 }
 
+// Gets the URL of the given path on the port Nginx listens on
+func (n NginxStaticService) getUrl(urlPath string) (string, error) {
+	port, err := services.GetPortByName(n.usedPorts, listenPortName)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred getting the Nginx listen port")
+	}
+	return fmt.Sprintf("http://%v:%v/%v", n.ipAddr, port.Number, urlPath), nil
+}
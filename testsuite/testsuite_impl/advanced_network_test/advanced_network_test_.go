@@ -31,30 +31,21 @@ func NewAdvancedNetworkTest(datastoreServiceImage string, apiServiceImage string
 	return &AdvancedNetworkTest{datastoreServiceImage: datastoreServiceImage, apiServiceImage: apiServiceImage}
 }
 
-func (test *AdvancedNetworkTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (test *AdvancedNetworkTest) Setup(networkCtx *networks.NetworkContext) (*networks_impl.TestNetwork, error) {
 	network := networks_impl.NewTestNetwork(networkCtx, test.datastoreServiceImage, test.apiServiceImage)
 
-	if err := network.AddDatastore(); err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred adding the datastore")
-	}
-
-	personModifyingApiServiceId, err := network.AddApiService()
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred adding the person-modifying API service")
-	}
-	test.personModifyingApiServiceId = personModifyingApiServiceId
-
-	personRetrievingApiServiceId, err := network.AddApiService()
+	// The API services are only started once the datastore they depend on is available
+	apiServiceIds, err := network.AddDatastoreAndApiServices(2)
 	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred adding the person-retrieving API service")
+		return nil, stacktrace.Propagate(err, "An error occurred adding the datastore and API services")
 	}
-	test.personRetrievingApiServiceId = personRetrievingApiServiceId
+	test.personModifyingApiServiceId = apiServiceIds[0]
+	test.personRetrievingApiServiceId = apiServiceIds[1]
 
 	return network, nil
 }
 
-func (test *AdvancedNetworkTest) Run(network networks.Network, testCtx testsuite.TestContext) {
-	castedNetwork := network.(*networks_impl.TestNetwork)
+func (test *AdvancedNetworkTest) Run(castedNetwork *networks_impl.TestNetwork, testCtx testsuite.TestContext) {
 	personModifier, err := castedNetwork.GetApiService(test.personModifyingApiServiceId)
 	if err != nil {
 		testCtx.Fatal(stacktrace.Propagate(err, "An error occurred getting the person-modifying API service"))
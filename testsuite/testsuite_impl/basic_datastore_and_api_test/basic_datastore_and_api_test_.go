@@ -36,9 +36,9 @@ func NewBasicDatastoreAndApiTest(datstoreImage string, apiImage string) *BasicDa
 	return &BasicDatastoreAndApiTest{datstoreImage: datstoreImage, apiImage: apiImage}
 }
 
-func (b BasicDatastoreAndApiTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (b BasicDatastoreAndApiTest) Setup(networkCtx *networks.NetworkContext) (*networks.NetworkContext, error) {
 	datastoreInitializer := datastore.NewDatastoreContainerInitializer(b.datstoreImage)
-	uncastedDatastoreSvc, datastoreChecker, err := networkCtx.AddService(datastoreServiceId, datastoreInitializer)
+	_, datastoreChecker, err := networks.AddTypedService[*datastore.DatastoreService](networkCtx, datastoreServiceId, datastoreInitializer)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "An error occurred adding the datastore service")
 	}
@@ -46,11 +46,11 @@ func (b BasicDatastoreAndApiTest) Setup(networkCtx *networks.NetworkContext) (ne
 		return nil, stacktrace.Propagate(err, "An error occurred waiting for the datastore service to start")
 	}
 
-	// Go doesn't have generics so we need to do this cast
-	datastoreSvc := uncastedDatastoreSvc.(*datastore.DatastoreService)
-
-	apiInitializer := api.NewApiContainerInitializer(b.apiImage, datastoreSvc)
-	_, apiChecker, err := networkCtx.AddService(apiServiceId, apiInitializer)
+	apiInitializer, err := api.NewApiContainerInitializer(b.apiImage, datastoreServiceId)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the API service's initializer")
+	}
+	_, apiChecker, err := networks.AddTypedService[*api.ApiService](networkCtx, apiServiceId, apiInitializer)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "An error occurred adding the API service")
 	}
@@ -61,15 +61,11 @@ func (b BasicDatastoreAndApiTest) Setup(networkCtx *networks.NetworkContext) (ne
 }
 
 
-func (b BasicDatastoreAndApiTest) Run(network networks.Network, testCtx testsuite.TestContext) {
-	// Go doesn't have generics so we have to do this cast first
-	castedNetwork := network.(*networks.NetworkContext)
-
-	uncastedApiService, err := castedNetwork.GetService(apiServiceId)
+func (b BasicDatastoreAndApiTest) Run(networkCtx *networks.NetworkContext, testCtx testsuite.TestContext) {
+	apiService, err := networks.GetTypedService[*api.ApiService](networkCtx, apiServiceId)
 	if err != nil {
 		testCtx.Fatal(stacktrace.Propagate(err, "An error occurred getting the API service"))
 	}
-	apiService := uncastedApiService.(*api.ApiService)
 
 	logrus.Infof("Verifying that person with test ID '%v' doesn't already exist...", testPersonId)
 	if _, err = apiService.GetPerson(testPersonId); err == nil {
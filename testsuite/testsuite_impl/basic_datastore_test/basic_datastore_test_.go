@@ -33,9 +33,9 @@ func NewBasicDatastoreTest(datastoreImage string) *BasicDatastoreTest {
 	return &BasicDatastoreTest{datastoreImage: datastoreImage}
 }
 
-func (test BasicDatastoreTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (test BasicDatastoreTest) Setup(networkCtx *networks.NetworkContext) (*networks.NetworkContext, error) {
 	datastoreContainerInitializer := datastore.NewDatastoreContainerInitializer(test.datastoreImage)
-	_, availabilityChecker, err := networkCtx.AddService(datastoreServiceId, datastoreContainerInitializer)
+	_, availabilityChecker, err := networks.AddTypedService[*datastore.DatastoreService](networkCtx, datastoreServiceId, datastoreContainerInitializer)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "An error occurred adding the datastore service")
 	}
@@ -45,18 +45,12 @@ func (test BasicDatastoreTest) Setup(networkCtx *networks.NetworkContext) (netwo
 	return networkCtx, nil
 }
 
-func (test BasicDatastoreTest) Run(network networks.Network, testCtx testsuite.TestContext) {
-	// Necessary because Go doesn't have generics
-	castedNetwork := network.(*networks.NetworkContext)
-
-	uncastedService, err := castedNetwork.GetService(datastoreServiceId)
+func (test BasicDatastoreTest) Run(networkCtx *networks.NetworkContext, testCtx testsuite.TestContext) {
+	castedService, err := networks.GetTypedService[*datastore.DatastoreService](networkCtx, datastoreServiceId)
 	if err != nil {
 		testCtx.Fatal(stacktrace.Propagate(err, "An error occurred getting the datastore service"))
 	}
 
-	// Necessary again due to no Go generics
-	castedService := uncastedService.(*datastore.DatastoreService)
-
 	logrus.Infof("Verifying that key '%v' doesn't already exist...", testKey)
 	exists, err := castedService.Exists(testKey)
 	if err != nil {
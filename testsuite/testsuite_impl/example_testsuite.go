@@ -6,7 +6,9 @@
 package testsuite_impl
 
 import (
+	"github.com/kurtosis-tech/kurtosis-go/lib/networks"
 	"github.com/kurtosis-tech/kurtosis-go/lib/testsuite"
+	"github.com/kurtosis-tech/kurtosis-go/testsuite/networks_impl"
 	"github.com/kurtosis-tech/kurtosis-go/testsuite/testsuite_impl/advanced_network_test"
 	"github.com/kurtosis-tech/kurtosis-go/testsuite/testsuite_impl/basic_datastore_and_api_test"
 	"github.com/kurtosis-tech/kurtosis-go/testsuite/testsuite_impl/basic_datastore_test"
@@ -26,14 +28,20 @@ func NewExampleTestsuite(apiServiceImage string, datastoreServiceImage string, i
 
 func (suite ExampleTestsuite) GetTests() map[string]testsuite.Test {
 	tests := map[string]testsuite.Test{
-		"basicDatastoreTest": basic_datastore_test.NewBasicDatastoreTest(suite.datastoreServiceImage),
-		"basicDatastoreAndApiTest": basic_datastore_and_api_test.NewBasicDatastoreAndApiTest(
-			suite.datastoreServiceImage,
-			suite.apiServiceImage,
+		"basicDatastoreTest": testsuite.NewUntypedTest[*networks.NetworkContext](
+			basic_datastore_test.NewBasicDatastoreTest(suite.datastoreServiceImage),
 		),
-		"advancedNetworkTest": advanced_network_test.NewAdvancedNetworkTest(
-			suite.datastoreServiceImage,
-			suite.apiServiceImage,
+		"basicDatastoreAndApiTest": testsuite.NewUntypedTest[*networks.NetworkContext](
+			basic_datastore_and_api_test.NewBasicDatastoreAndApiTest(
+				suite.datastoreServiceImage,
+				suite.apiServiceImage,
+			),
+		),
+		"advancedNetworkTest": testsuite.NewUntypedTest[*networks_impl.TestNetwork](
+			advanced_network_test.NewAdvancedNetworkTest(
+				suite.datastoreServiceImage,
+				suite.apiServiceImage,
+			),
 		),
 	}
 
@@ -42,9 +50,11 @@ func (suite ExampleTestsuite) GetTests() map[string]testsuite.Test {
 	//  to you) are run
 	// Feel free to delete these tests as you see fit
 	if suite.isKurtosisCoreDevMode {
-		tests["networkPartitionTest"] = network_partition_test.NewNetworkPartitionTest(
-			suite.datastoreServiceImage,
-			suite.apiServiceImage,
+		tests["networkPartitionTest"] = testsuite.NewUntypedTest[*networks.NetworkContext](
+			network_partition_test.NewNetworkPartitionTest(
+				suite.datastoreServiceImage,
+				suite.apiServiceImage,
+			),
 		)
 		tests["filesArtifactMountingTest"] = files_artifact_mounting_test.FilesArtifactMountingTest{}
 	}
@@ -20,8 +20,9 @@ const (
 	waitForStartupTimeBetweenPolls = 1 * time.Second
 	waitForStartupMaxRetries = 5
 
-	testFilesArtifactId  services.FilesArtifactID = "test-files-artifact"
-	testFilesArtifactUrl                          = "https://kurtosis-public-access.s3.us-east-1.amazonaws.com/test-artifacts/static-fileserver-files.tgz"
+	testFilesArtifactId services.FilesArtifactID = "test-files-artifact"
+	// Baked into the testsuite image by its Dockerfile
+	testFilesArtifactPath = "/static-files/files_artifact_mounting_test"
 
 	// Filenames & contents for the files stored in the files artifact
 	file1Filename = "file1.txt"
@@ -35,8 +36,8 @@ type FilesArtifactMountingTest struct {}
 
 func (f FilesArtifactMountingTest) GetTestConfiguration() testsuite.TestConfiguration {
 	return testsuite.TestConfiguration{
-		FilesArtifactUrls: map[services.FilesArtifactID]string{
-			testFilesArtifactId: testFilesArtifactUrl,
+		LocalFilesArtifacts: map[services.FilesArtifactID]string{
+			testFilesArtifactId: testFilesArtifactPath,
 		},
 	}
 }
@@ -57,15 +58,9 @@ func (f FilesArtifactMountingTest) Run(network networks.Network, testCtx testsui
 	// Only necessary because Go doesn't have generics
 	castedNetwork := network.(*networks.NetworkContext)
 
-	uncastedService, err := castedNetwork.GetService(fileServerServiceId)
+	castedService, err := networks.GetTypedService[*nginx_static.NginxStaticService](castedNetwork, fileServerServiceId)
 	if err != nil {
-		testCtx.Fatal(stacktrace.Propagate(err, "An error occurred retrieving the fileserver service"))
-	}
-
-	// Only necessary because Go doesn't have generics
-	castedService, castErrOccurred := uncastedService.(*nginx_static.NginxStaticService)
-	if castErrOccurred {
-		testCtx.Fatal(stacktrace.Propagate(err, "An error occurred casting the file server service API"))
+		testCtx.Fatal(stacktrace.Propagate(err, "An error occurred retrieving the file server service"))
 	}
 
 	file1Contents, err := castedService.GetFileContents(file1Filename)
@@ -42,9 +42,9 @@ func NewNetworkPartitionTest(datstoreImage string, apiImage string) *NetworkPart
 }
 
 // Instantiates the network with no partition and one person in the datatstore
-func (test NetworkPartitionTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+func (test NetworkPartitionTest) Setup(networkCtx *networks.NetworkContext) (*networks.NetworkContext, error) {
 	datastoreInitializer := datastore.NewDatastoreContainerInitializer(test.datstoreImage)
-	uncastedDatastoreSvc, datastoreChecker, err := networkCtx.AddService(datastoreServiceId, datastoreInitializer)
+	_, datastoreChecker, err := networks.AddTypedService[*datastore.DatastoreService](networkCtx, datastoreServiceId, datastoreInitializer)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "An error occurred adding the datastore service")
 	}
@@ -52,10 +52,7 @@ func (test NetworkPartitionTest) Setup(networkCtx *networks.NetworkContext) (net
 		return nil, stacktrace.Propagate(err, "An error occurred waiting for the datastore service to start")
 	}
 
-	// Go doesn't have generics so we need to do this cast
-	datastoreSvc := uncastedDatastoreSvc.(*datastore.DatastoreService)
-
-	apiSvc, err := test.addApiService(networkCtx, api1ServiceId, defaultPartitionId, datastoreSvc)
+	apiSvc, err := test.addApiService(networkCtx, api1ServiceId, defaultPartitionId)
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "An error occurred adding service '%v'", api1ServiceId)
 	}
@@ -71,10 +68,7 @@ func (test NetworkPartitionTest) Setup(networkCtx *networks.NetworkContext) (net
 }
 
 
-func (test NetworkPartitionTest) Run(network networks.Network, testCtx testsuite.TestContext) {
-	// Go doesn't have generics so we have to do this cast first
-	castedNetwork := network.(*networks.NetworkContext)
-
+func (test NetworkPartitionTest) Run(castedNetwork *networks.NetworkContext, testCtx testsuite.TestContext) {
 
 	logrus.Info("Partitioning API and datastore services off from each other...")
 	blockedConnRepartitioner, err := getTwoPartitionsRepartitioner(castedNetwork, true, false)
@@ -87,11 +81,10 @@ func (test NetworkPartitionTest) Run(network networks.Network, testCtx testsuite
 	logrus.Info("Repartition complete")
 
 	logrus.Info("Incrementing books read via API 1 while partition is in place, to verify no comms are possible...")
-	uncastedApi1Service, err := castedNetwork.GetService(api1ServiceId)
+	api1Service, err := networks.GetTypedService[*api.ApiService](castedNetwork, api1ServiceId)
 	if err != nil {
 		testCtx.Fatal(stacktrace.Propagate(err, "An error occurred getting the API 1 service interface"))
 	}
-	api1Service := uncastedApi1Service.(*api.ApiService) // Necessary because Go doesn't have generics
 	if err := api1Service.IncrementBooksRead(testPersonId); err == nil {
 		testCtx.Fatal(stacktrace.NewError("Expected the book increment call via API 1 to fail due to the network " +
 			"partition between API and datastore services, but no error was thrown"))
@@ -101,15 +94,7 @@ func (test NetworkPartitionTest) Run(network networks.Network, testCtx testsuite
 
 	// Adding another API service while the partition is in place ensures that partitiong works even when you add a node
 	logrus.Info("Adding second API container, to ensure adding a network under partition works...")
-	uncastedDatastoreSvc, err := castedNetwork.GetService(datastoreServiceId)
-	if err != nil {
-		testCtx.Fatal(stacktrace.Propagate(err, "An error occurred getting the datastore service interface"))
-	}
-	api2Service, err := test.addApiService(
-		castedNetwork,
-		api2ServiceId,
-		apiPartitionId,
-		uncastedDatastoreSvc.(*datastore.DatastoreService))
+	api2Service, err := test.addApiService(castedNetwork, api2ServiceId, apiPartitionId)
 	if err != nil {
 		testCtx.Fatal(stacktrace.Propagate(err, "An error occurred adding the second API service to the network"))
 	}
@@ -178,17 +163,19 @@ func (test NetworkPartitionTest) GetSetupTeardownBuffer() time.Duration {
 func (test NetworkPartitionTest) addApiService(
 		networkCtx *networks.NetworkContext,
 		serviceId services.ServiceID,
-		partitionId networks.PartitionID,
-		datastoreSvc *datastore.DatastoreService) (*api.ApiService, error) {
-	apiInitializer := api.NewApiContainerInitializer(test.apiImage, datastoreSvc)
-	uncastedApiSvc, apiChecker, err := networkCtx.AddServiceToPartition(serviceId, partitionId, apiInitializer)
+		partitionId networks.PartitionID) (*api.ApiService, error) {
+	apiInitializer, err := api.NewApiContainerInitializer(test.apiImage, datastoreServiceId)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the API service's initializer")
+	}
+	apiSvc, apiChecker, err := networks.AddTypedService[*api.ApiService](networkCtx, serviceId, apiInitializer, networks.WithPartition(partitionId))
 	if err != nil {
 		return nil, stacktrace.Propagate(err, "An error occurred adding the API service")
 	}
 	if err := apiChecker.WaitForStartup(waitForStartupTimeBetweenPolls, waitForStartupMaxNumPolls); err != nil {
 		return nil, stacktrace.Propagate(err, "An error occurred waiting for the API service to start")
 	}
-	return uncastedApiSvc.(*api.ApiService), nil
+	return apiSvc, nil
 }
 
 /*